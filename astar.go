@@ -0,0 +1,263 @@
+package grid
+
+import "math"
+
+// Heuristic represents a function that estimates the cost of a path between
+// two positions. For A* to return shortest paths, it should never
+// overestimate the real cost.
+type Heuristic func(p, q Point) float64
+
+// ManhattanHeuristic returns the Manhattan distance between p and q. It is
+// suited for 4-connected movement with unit cost.
+func ManhattanHeuristic(p, q Point) float64 {
+	return float64(p.Manhattan(q))
+}
+
+// ChebyshevHeuristic returns the Chebyshev distance between p and q. It is
+// suited for 8-connected movement where diagonal moves cost the same as
+// cardinal ones.
+func ChebyshevHeuristic(p, q Point) float64 {
+	return float64(p.Chebyshev(q))
+}
+
+// OctileHeuristic returns the octile distance between p and q. It is suited
+// for 8-connected movement where diagonal moves cost sqrt(2).
+func OctileHeuristic(p, q Point) float64 {
+	d := p.Sub(q).Abs()
+	return float64(max(d.X, d.Y)) + (math.Sqrt2-1)*float64(min(d.X, d.Y))
+}
+
+// EuclideanHeuristic returns the Euclidean distance between p and q.
+func EuclideanHeuristic(p, q Point) float64 {
+	return p.Euclidean(q)
+}
+
+// DiagonalRule represents a rule for diagonal movement in path finding.
+type DiagonalRule int
+
+// Available diagonal movement rules. For the last two, a diagonal move from p
+// to q is checked against the two positions adjacent to both p and q, which
+// are blocked if moving there from p is not possible.
+const (
+	// DiagonalNever allows only cardinal moves.
+	DiagonalNever DiagonalRule = iota
+	// DiagonalAlways allows diagonal moves, even between two blocked
+	// positions.
+	DiagonalAlways
+	// DiagonalNoSqueeze allows diagonal moves unless both adjacent
+	// positions are blocked.
+	DiagonalNoSqueeze
+	// DiagonalNoCornerCut allows diagonal moves only if both adjacent
+	// positions are free.
+	DiagonalNoCornerCut
+)
+
+// PathFinder computes shortest paths within a range using the A* algorithm.
+// It keeps its internal buffers across calls, so that searches do not
+// allocate once buffers have grown. A PathFinder should not be used
+// concurrently.
+//
+// The Cost field is required. Other fields may be modified between calls.
+type PathFinder struct {
+	// Cost returns the cost of moving from p to the adjacent position q,
+	// which should be positive, and whether the move is possible.
+	Cost func(p, q Point) (float64, bool)
+	// Heuristic estimates the remaining cost to the goal. If nil, no
+	// heuristic is used, and the search is equivalent to Dijkstra's
+	// algorithm.
+	Heuristic Heuristic
+	// Diagonals determines whether and when diagonal moves are allowed.
+	Diagonals DiagonalRule
+
+	search
+}
+
+// search represents the reusable state of an A* search over the positions of
+// a range.
+type search struct {
+	rg     Range
+	g      []float64         // cost from start
+	parent []int32           // parent index
+	seen   []uint32          // generation at which a node was reached
+	closed []uint32          // generation at which a node was expanded
+	gen    uint32            // current search generation
+	open   minHeap[pathNode] // nodes to expand
+}
+
+type pathNode struct {
+	i int32   // node index
+	f float64 // estimated total cost
+	g float64 // cost from start
+}
+
+// NewPathFinder returns a path finder for positions within range rg, using
+// the given cost function, octile heuristic and diagonal moves without
+// corner cutting.
+func NewPathFinder(rg Range, cost func(p, q Point) (float64, bool)) *PathFinder {
+	pf := &PathFinder{
+		Cost:      cost,
+		Heuristic: OctileHeuristic,
+		Diagonals: DiagonalNoCornerCut,
+	}
+	pf.init(rg)
+	return pf
+}
+
+// init allocates the search buffers for range rg.
+func (s *search) init(rg Range) {
+	s.rg = rg
+	max := rg.Size()
+	n := max.X * max.Y
+	if rg.Empty() {
+		n = 0
+	}
+	s.g = make([]float64, n)
+	s.parent = make([]int32, n)
+	s.seen = make([]uint32, n)
+	s.closed = make([]uint32, n)
+}
+
+// Range returns the range of positions considered by the path finder.
+func (pf *PathFinder) Range() Range {
+	return pf.rg
+}
+
+func (s *search) idx(p Point) int32 {
+	return int32((p.Y-s.rg.Min.Y)*(s.rg.Max.X-s.rg.Min.X) + p.X - s.rg.Min.X)
+}
+
+func (s *search) point(i int32) Point {
+	w := int32(s.rg.Max.X - s.rg.Min.X)
+	return s.rg.Min.Shift(int(i%w), int(i/w))
+}
+
+// start starts a new search from node i, with estimated total cost f. It
+// uses a new search generation, so that marks from previous searches become
+// obsolete without clearing.
+func (s *search) start(i int32, f float64) {
+	s.gen++
+	if s.gen == 0 {
+		clear(s.seen)
+		clear(s.closed)
+		s.gen = 1
+	}
+	s.open = s.open[:0]
+	s.g[i] = 0
+	s.seen[i] = s.gen
+	s.open.push(pathNode{i: i, f: f})
+}
+
+// next returns the next node to expand, marking it as closed, or false if
+// there are no more nodes.
+func (s *search) next() (pathNode, bool) {
+	for len(s.open) > 0 {
+		n := s.open.pop()
+		if s.closed[n.i] == s.gen || n.g > s.g[n.i] {
+			// outdated entry
+			continue
+		}
+		s.closed[n.i] = s.gen
+		return n, true
+	}
+	return pathNode{}, false
+}
+
+// relax updates node qi if reaching it with cost g from node pi improves its
+// cost, using h as estimate of the remaining cost.
+func (s *search) relax(qi, pi int32, g, h float64) {
+	if s.closed[qi] == s.gen || s.seen[qi] == s.gen && g >= s.g[qi] {
+		return
+	}
+	s.seen[qi] = s.gen
+	s.g[qi] = g
+	s.parent[qi] = pi
+	s.open.push(pathNode{i: qi, f: g + h, g: g})
+}
+
+// Path appends to buf[:0] a shortest path from position from to position to,
+// both included, and returns the updated slice. It returns an empty slice if
+// there is no path, or if from or to are out of the path finder's range.
+func (pf *PathFinder) Path(buf []Point, from, to Point) []Point {
+	buf = buf[:0]
+	if !from.In(pf.rg) || !to.In(pf.rg) {
+		return buf
+	}
+	start, goal := pf.idx(from), pf.idx(to)
+	pf.start(start, pf.h(from, to))
+	st := Cardinal
+	if pf.Diagonals != DiagonalNever {
+		st = Moore
+	}
+	for {
+		n, ok := pf.next()
+		if !ok {
+			return buf
+		}
+		if n.i == goal {
+			return pf.appendPath(buf, start, goal)
+		}
+		p := pf.point(n.i)
+		for _, d := range st {
+			q := p.Add(d)
+			if !q.In(pf.rg) {
+				continue
+			}
+			if d.X != 0 && d.Y != 0 && !pf.diagonalAllowed(p, d) {
+				continue
+			}
+			c, ok := pf.Cost(p, q)
+			if !ok {
+				continue
+			}
+			pf.relax(pf.idx(q), n.i, n.g+c, pf.h(q, to))
+		}
+	}
+}
+
+func (pf *PathFinder) h(p, q Point) float64 {
+	if pf.Heuristic == nil {
+		return 0
+	}
+	return pf.Heuristic(p, q)
+}
+
+// diagonalAllowed reports whether the diagonal move from p by d is allowed
+// by the diagonal rule.
+func (pf *PathFinder) diagonalAllowed(p Point, d Point) bool {
+	if pf.Diagonals == DiagonalAlways {
+		return true
+	}
+	free := func(q Point) bool {
+		if !q.In(pf.rg) {
+			return false
+		}
+		_, ok := pf.Cost(p, q)
+		return ok
+	}
+	a, b := free(p.Shift(d.X, 0)), free(p.Shift(0, d.Y))
+	if pf.Diagonals == DiagonalNoSqueeze {
+		return a || b
+	}
+	return a && b
+}
+
+// appendPath appends to buf the path from start to goal following parent
+// links.
+func (s *search) appendPath(buf []Point, start, goal int32) []Point {
+	for i := goal; ; i = s.parent[i] {
+		buf = append(buf, s.point(i))
+		if i == start {
+			break
+		}
+	}
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+	return buf
+}
+
+// less orders nodes by estimated total cost, breaking ties in favor of nodes
+// closer to the goal.
+func (n pathNode) less(m pathNode) bool {
+	return n.f < m.f || n.f == m.f && n.g > m.g
+}
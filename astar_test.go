@@ -0,0 +1,153 @@
+package grid
+
+import (
+	"math"
+	"testing"
+)
+
+func mazeCost(gd Grid[rune]) func(p, q Point) (float64, bool) {
+	return func(p, q Point) (float64, bool) {
+		if gd.At(q) == '#' {
+			return 0, false
+		}
+		if p.X != q.X && p.Y != q.Y {
+			return math.Sqrt2, true
+		}
+		return 1, true
+	}
+}
+
+func TestHeuristics(t *testing.T) {
+	p, q := Point{1, 1}, Point{4, 5}
+	if ManhattanHeuristic(p, q) != 7 || ChebyshevHeuristic(p, q) != 4 || EuclideanHeuristic(p, q) != 5 {
+		t.Errorf("bad heuristics")
+	}
+	if h := OctileHeuristic(p, q); math.Abs(h-(1+3*math.Sqrt2)) > 1e-9 {
+		t.Errorf("bad octile heuristic: %v", h)
+	}
+}
+
+func TestPathFinder(t *testing.T) {
+	gd := newMaze(
+		"..........",
+		".########.",
+		".#......#.",
+		".#.####.#.",
+		"...#..#...",
+		"####..####",
+	)
+	pf := NewPathFinder(gd.Range(), mazeCost(gd))
+	pf.Diagonals = DiagonalNever
+	pf.Heuristic = ManhattanHeuristic
+	path := pf.Path(nil, Point{0, 0}, Point{2, 4})
+	if len(path) == 0 {
+		t.Fatalf("no path found")
+	}
+	if path[0] != (Point{0, 0}) || path[len(path)-1] != (Point{2, 4}) {
+		t.Errorf("bad endpoints: %v", path)
+	}
+	for i := 1; i < len(path); i++ {
+		if path[i].Manhattan(path[i-1]) != 1 || gd.At(path[i]) == '#' {
+			t.Errorf("bad path step %v -> %v", path[i-1], path[i])
+		}
+	}
+	var dm DistanceMapper
+	dist := NewGrid[int](10, 6)
+	dm.BFS(dist, []Point{{0, 0}}, Conn4, func(p Point) bool { return gd.At(p) != '#' })
+	if len(path)-1 != dist.At(Point{2, 4}) {
+		t.Errorf("non shortest path: %d vs %d", len(path)-1, dist.At(Point{2, 4}))
+	}
+	if path := pf.Path(path, Point{0, 0}, Point{4, 4}); len(path) != 0 {
+		t.Errorf("found path to unreachable position: %v", path)
+	}
+	if path := pf.Path(path, Point{0, 0}, Point{20, 2}); len(path) != 0 {
+		t.Errorf("found path out of range: %v", path)
+	}
+	if path := pf.Path(path, Point{3, 2}, Point{3, 2}); len(path) != 1 {
+		t.Errorf("bad trivial path: %v", path)
+	}
+}
+
+func TestPathFinderDiagonals(t *testing.T) {
+	gd := newMaze(
+		".#.",
+		"#..",
+		"...",
+	)
+	pf := NewPathFinder(gd.Range(), mazeCost(gd))
+	for _, rule := range []DiagonalRule{DiagonalNever, DiagonalNoSqueeze, DiagonalNoCornerCut} {
+		pf.Diagonals = rule
+		if path := pf.Path(nil, Point{0, 0}, Point{1, 1}); len(path) != 0 {
+			t.Errorf("%d: found squeezing path: %v", rule, path)
+		}
+	}
+	pf.Diagonals = DiagonalAlways
+	if path := pf.Path(nil, Point{0, 0}, Point{1, 1}); len(path) != 2 {
+		t.Errorf("bad diagonal path: %v", path)
+	}
+	pf.Diagonals = DiagonalNoSqueeze
+	if path := pf.Path(nil, Point{2, 0}, Point{1, 1}); len(path) != 2 {
+		t.Errorf("bad corner cutting path: %v", path)
+	}
+	pf.Diagonals = DiagonalNoCornerCut
+	if path := pf.Path(nil, Point{2, 0}, Point{1, 1}); len(path) != 3 {
+		t.Errorf("bad path without corner cutting: %v", path)
+	}
+}
+
+func TestPathFinderOptimal(t *testing.T) {
+	gd := NewGrid[rune](40, 30)
+	rg := gd.Bounds().Add(Point{5, -3})
+	for i := 0; i < 10; i++ {
+		gd.FillFunc(func(p Point) rune {
+			if randInt(4) == 0 {
+				return '#'
+			}
+			return '.'
+		})
+		at := func(p Point) rune { return gd.At(p.Sub(rg.Min)) }
+		pf := NewPathFinder(rg, func(p, q Point) (float64, bool) { return 1, at(q) != '#' })
+		pf.Diagonals = DiagonalAlways
+		pf.Heuristic = ChebyshevHeuristic
+		from, to := rg.Min.Shift(randInt(40), randInt(30)), rg.Min.Shift(randInt(40), randInt(30))
+		var dm DistanceMapper
+		dist := NewGrid[int](40, 30)
+		dm.BFS(dist, []Point{from.Sub(rg.Min)}, Conn8, func(p Point) bool { return gd.At(p) != '#' })
+		path := pf.Path(nil, from, to)
+		d := dist.At(to.Sub(rg.Min))
+		if d == Unreachable {
+			if len(path) != 0 {
+				t.Errorf("path found to unreachable position")
+			}
+			continue
+		}
+		if len(path)-1 != d {
+			t.Errorf("non shortest path from %v to %v: %d vs %d", from, to, len(path)-1, d)
+		}
+	}
+}
+
+func TestPathFinderAllocs(t *testing.T) {
+	gd := NewGrid[rune](80, 24)
+	gd.Fill('.')
+	gd.Slice(NewRange(40, 0, 41, 20)).Fill('#')
+	pf := NewPathFinder(gd.Range(), mazeCost(gd))
+	path := pf.Path(nil, Point{0, 0}, Point{79, 0})
+	allocs := testing.AllocsPerRun(10, func() {
+		path = pf.Path(path, Point{0, 0}, Point{79, 0})
+	})
+	if allocs != 0 {
+		t.Errorf("allocations in path search: %v", allocs)
+	}
+}
+
+func BenchmarkPathFinder(b *testing.B) {
+	gd := NewGrid[rune](80, 24)
+	gd.Fill('.')
+	gd.Slice(NewRange(40, 0, 41, 20)).Fill('#')
+	pf := NewPathFinder(gd.Range(), mazeCost(gd))
+	var path []Point
+	for i := 0; i < b.N; i++ {
+		path = pf.Path(path, Point{0, 0}, Point{79, 0})
+	}
+}
@@ -0,0 +1,229 @@
+package grid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EdgeMode represents how positions out of a grid are handled when they are
+// part of the neighborhood of a position within the grid.
+type EdgeMode int
+
+// Available edge modes.
+const (
+	// EdgeBounded ignores positions out of the grid, so that positions
+	// near the edges have fewer neighbors.
+	EdgeBounded EdgeMode = iota
+	// EdgeWrap wraps around positions out of the grid, as on a torus.
+	EdgeWrap
+	// EdgeConstant gives a constant value to positions out of the grid.
+	EdgeConstant
+	// EdgeClamp replaces positions out of the grid by the nearest
+	// position in the grid.
+	EdgeClamp
+	// EdgeMirror reflects positions out of the grid across its edges,
+	// with edge positions repeated: on each axis, coordinate -1 maps to
+	// 0, and -2 to 1.
+	EdgeMirror
+)
+
+// coord maps coordinate i to the range [0, n) according to the edge mode. It
+// returns false if i is out of the range and does not correspond to any
+// coordinate in it.
+func (mode EdgeMode) coord(i, n int) (int, bool) {
+	if i >= 0 && i < n {
+		return i, true
+	}
+	switch mode {
+	case EdgeWrap:
+		i %= n
+		if i < 0 {
+			i += n
+		}
+		return i, true
+	case EdgeClamp:
+		return min(max(i, 0), n-1), true
+	case EdgeMirror:
+		i %= 2 * n
+		if i < 0 {
+			i += 2 * n
+		}
+		if i >= n {
+			i = 2*n - 1 - i
+		}
+		return i, true
+	default:
+		return i, false
+	}
+}
+
+// Automaton represents a cellular automaton over a grid. At each step, every
+// cell is updated simultaneously from its value and the values of its
+// neighbors.
+//
+// An automaton keeps two grids of the same size, the current state and a
+// buffer for the next one, which are swapped after each step, so that
+// stepping does not allocate. An automaton should not be used concurrently.
+type Automaton[T any] struct {
+	// Rule returns the next value of a cell, given its current value and
+	// the current values of its neighbors, in the order given by the
+	// stencil. The neighbors slice is reused by the automaton and should
+	// not be retained.
+	Rule func(cell T, neighbors []T) T
+	// Stencil is the neighborhood of a position. If nil, Moore is used.
+	Stencil Stencil
+	// Edge determines how neighbors out of the grid are handled.
+	Edge EdgeMode
+	// Border is the value of positions out of the grid for EdgeConstant.
+	Border T
+
+	front Grid[T] // current state
+	back  Grid[T] // next state buffer
+	nbuf  []T     // neighbors buffer
+}
+
+// NewAutomaton returns a new automaton using the given rule, with an initial
+// state copied from gd, the Moore neighborhood, and bounded edges.
+func NewAutomaton[T any](gd Grid[T], rule func(cell T, neighbors []T) T) *Automaton[T] {
+	max := gd.Size()
+	a := &Automaton[T]{
+		Rule:  rule,
+		front: NewGrid[T](max.X, max.Y),
+		back:  NewGrid[T](max.X, max.Y),
+	}
+	a.front.Copy(gd)
+	return a
+}
+
+// Grid returns the automaton's current state. It may be modified between
+// steps, but it should not be retained after the next step, as its
+// underlying storage is then reused.
+func (a *Automaton[T]) Grid() Grid[T] {
+	return a.front
+}
+
+// Step advances the automaton by n steps.
+func (a *Automaton[T]) Step(n int) {
+	st := a.Stencil
+	if st == nil {
+		st = Moore
+	}
+	max := a.front.Size()
+	for ; n > 0; n-- {
+		cells := a.front.ug.Cells
+		next := a.back.ug.Cells
+		for i, c := range cells {
+			p := Point{i % max.X, i / max.X}
+			nbs := a.nbuf[:0]
+			for _, d := range st {
+				qx, okx := a.Edge.coord(p.X+d.X, max.X)
+				qy, oky := a.Edge.coord(p.Y+d.Y, max.Y)
+				switch {
+				case okx && oky:
+					nbs = append(nbs, cells[qy*max.X+qx])
+				case a.Edge == EdgeConstant:
+					nbs = append(nbs, a.Border)
+				}
+			}
+			a.nbuf = nbs
+			next[i] = a.Rule(c, nbs)
+		}
+		a.front, a.back = a.back, a.front
+	}
+}
+
+// LifeRule represents a Life-like rule for boolean automata, as sets of
+// numbers of live neighbors: a dead cell becomes alive if its number of live
+// neighbors is in Birth, and a live cell stays alive if it is in Survival.
+// Bit i of each set corresponds to i live neighbors.
+type LifeRule struct {
+	Birth    uint32
+	Survival uint32
+}
+
+// ParseLifeRule parses a rule in B/S notation, such as "B3/S23" for Conway's
+// Game of Life, or "B678/S345678" for a cave generation rule. The letters
+// are case-insensitive, and the two parts may be given in any order.
+func ParseLifeRule(s string) (LifeRule, error) {
+	var r LifeRule
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return r, fmt.Errorf("bad life rule %q: expected two parts separated by /", s)
+	}
+	var seen [2]bool
+	for _, part := range parts {
+		if part == "" {
+			return r, fmt.Errorf("bad life rule %q: empty part", s)
+		}
+		var set *uint32
+		var k int
+		switch part[0] {
+		case 'B', 'b':
+			set, k = &r.Birth, 0
+		case 'S', 's':
+			set, k = &r.Survival, 1
+		default:
+			return r, fmt.Errorf("bad life rule %q: part %q does not start with B or S", s, part)
+		}
+		if seen[k] {
+			return r, fmt.Errorf("bad life rule %q: duplicate part %q", s, part[:1])
+		}
+		seen[k] = true
+		for _, c := range part[1:] {
+			if c < '0' || c > '8' {
+				return r, fmt.Errorf("bad life rule %q: invalid count %q", s, c)
+			}
+			*set |= 1 << (c - '0')
+		}
+	}
+	return r, nil
+}
+
+// MustParseLifeRule is like ParseLifeRule but panics if the rule cannot be
+// parsed. It simplifies safe initialization of global variables holding
+// rules.
+func MustParseLifeRule(s string) LifeRule {
+	r, err := ParseLifeRule(s)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
+
+// String returns the rule in B/S notation, such as "B3/S23". As with
+// ParseLifeRule, only counts from 0 to 8 are represented, so that the result
+// can be parsed back.
+func (r LifeRule) String() string {
+	var sb strings.Builder
+	sb.WriteByte('B')
+	for i := 0; i <= 8; i++ {
+		if r.Birth&(1<<i) != 0 {
+			sb.WriteByte('0' + byte(i))
+		}
+	}
+	sb.WriteString("/S")
+	for i := 0; i <= 8; i++ {
+		if r.Survival&(1<<i) != 0 {
+			sb.WriteByte('0' + byte(i))
+		}
+	}
+	return sb.String()
+}
+
+// Apply returns the next value of a cell given its current value and the
+// values of its neighbors. It can be used as an automaton rule.
+func (r LifeRule) Apply(cell bool, neighbors []bool) bool {
+	n := 0
+	for _, nb := range neighbors {
+		if nb {
+			n++
+		}
+	}
+	if n >= 32 {
+		return false
+	}
+	if cell {
+		return r.Survival&(1<<n) != 0
+	}
+	return r.Birth&(1<<n) != 0
+}
@@ -0,0 +1,127 @@
+package grid
+
+import "testing"
+
+func lifeGrid(lines ...string) Grid[bool] {
+	maze := newMaze(lines...)
+	max := maze.Size()
+	gd := NewGrid[bool](max.X, max.Y)
+	gd.Map(func(p Point, _ bool) bool { return maze.At(p) == '#' })
+	return gd
+}
+
+func equalGrids[T comparable](gd1, gd2 Grid[T]) bool {
+	if gd1.Size() != gd2.Size() {
+		return false
+	}
+	for p, c := range gd1.All() {
+		if gd2.At(p) != c {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParseLifeRule(t *testing.T) {
+	r, err := ParseLifeRule("B3/S23")
+	if err != nil || r.Birth != 1<<3 || r.Survival != 1<<2|1<<3 {
+		t.Errorf("bad rule: %+v (%v)", r, err)
+	}
+	if r.String() != "B3/S23" {
+		t.Errorf("bad rule string: %s", r)
+	}
+	if s := (LifeRule{Birth: 1<<1 | 1<<12, Survival: 1 << 8}).String(); s != "B1/S8" {
+		t.Errorf("bad rule string with large counts: %s", s)
+	}
+	if r2, err := ParseLifeRule("s23/b3"); err != nil || r2 != r {
+		t.Errorf("bad reversed rule: %+v (%v)", r2, err)
+	}
+	for _, s := range []string{"", "B3", "B3/S2/S3", "B3/B2", "X3/S23", "B9/S23", "B3/"} {
+		if _, err := ParseLifeRule(s); err == nil {
+			t.Errorf("no error for rule %q", s)
+		}
+	}
+	testPanic(t, func() { MustParseLifeRule("B3") }, "parsing a bad rule")
+}
+
+func TestAutomatonLife(t *testing.T) {
+	blinker := lifeGrid(
+		".....",
+		"..#..",
+		"..#..",
+		"..#..",
+		".....",
+	)
+	a := NewAutomaton(blinker, MustParseLifeRule("B3/S23").Apply)
+	a.Step(1)
+	want := lifeGrid(
+		".....",
+		".....",
+		".###.",
+		".....",
+		".....",
+	)
+	if !equalGrids(a.Grid(), want) {
+		t.Errorf("bad blinker step")
+	}
+	a.Step(1)
+	if !equalGrids(a.Grid(), blinker) {
+		t.Errorf("bad blinker period")
+	}
+	// A glider on a torus comes back to its initial position after 4*w
+	// generations.
+	glider := lifeGrid(
+		".#....",
+		"..#...",
+		"###...",
+		"......",
+		"......",
+		"......",
+	)
+	a = NewAutomaton(glider, MustParseLifeRule("B3/S23").Apply)
+	a.Edge = EdgeWrap
+	a.Step(24)
+	if !equalGrids(a.Grid(), glider) {
+		t.Errorf("bad glider on torus")
+	}
+}
+
+func TestAutomatonEdges(t *testing.T) {
+	count := func(cell int, nbs []int) int {
+		n := 0
+		for _, c := range nbs {
+			n += c
+		}
+		return n
+	}
+	gd := NewGrid[int](3, 3)
+	gd.Fill(1)
+	a := NewAutomaton(gd, count)
+	a.Step(1)
+	if a.Grid().At(Point{0, 0}) != 3 || a.Grid().At(Point{1, 0}) != 5 || a.Grid().At(Point{1, 1}) != 8 {
+		t.Errorf("bad bounded counts")
+	}
+	a = NewAutomaton(gd, count)
+	a.Edge = EdgeWrap
+	a.Step(1)
+	if a.Grid().At(Point{0, 0}) != 8 || a.Grid().At(Point{2, 1}) != 8 {
+		t.Errorf("bad wrapped counts")
+	}
+	a = NewAutomaton(gd, count)
+	a.Edge = EdgeClamp
+	a.Step(1)
+	if a.Grid().At(Point{0, 0}) != 8 {
+		t.Errorf("bad clamped counts")
+	}
+	a = NewAutomaton(gd, count)
+	a.Edge = EdgeConstant
+	a.Border = 10
+	a.Stencil = Cardinal
+	a.Step(1)
+	if a.Grid().At(Point{0, 0}) != 22 || a.Grid().At(Point{1, 1}) != 4 {
+		t.Errorf("bad constant border counts")
+	}
+	if gd.At(Point{1, 1}) != 1 {
+		t.Errorf("initial grid modified")
+	}
+}
@@ -0,0 +1,163 @@
+package grid
+
+import "math/bits"
+
+// Direction represents one of the eight compass directions. The Y axis points
+// down, as in the rest of the package, so that N corresponds to the (0,-1)
+// delta.
+type Direction uint8
+
+// The eight compass directions, in clockwise order starting from north, and
+// the special NoDirection value.
+const (
+	N Direction = iota
+	NE
+	E
+	SE
+	S
+	SW
+	W
+	NW
+	// NoDirection is not a proper direction. It is returned by
+	// p.Dir(p).
+	NoDirection
+)
+
+var dirDeltas = [...]Point{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, 0}}
+
+var dirNames = [...]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW", "NoDirection"}
+
+// String returns the short compass name of the direction, such as "N" or
+// "SW".
+func (d Direction) String() string {
+	if d > NoDirection {
+		return "Direction(?)"
+	}
+	return dirNames[d]
+}
+
+// Delta returns the unit vector associated with the direction, such that
+// p.Add(d.Delta()) is the neighbor of p in direction d. It returns the zero
+// point for NoDirection.
+func (d Direction) Delta() Point {
+	if d > NoDirection {
+		return Point{}
+	}
+	return dirDeltas[d]
+}
+
+// Rotate returns the direction rotated clockwise by n eighths of a turn. A
+// negative n means a counter-clockwise rotation. NoDirection is returned
+// unchanged.
+func (d Direction) Rotate(n int) Direction {
+	if d >= NoDirection {
+		return d
+	}
+	return Direction((int(d) + n%8 + 8) % 8)
+}
+
+// RotateCW returns the next direction in clockwise order, that is, the
+// direction rotated by 45 degrees clockwise. Use Rotate(2) for a quarter turn.
+func (d Direction) RotateCW() Direction {
+	return d.Rotate(1)
+}
+
+// RotateCCW returns the next direction in counter-clockwise order, that is,
+// the direction rotated by 45 degrees counter-clockwise. Use Rotate(-2) for a
+// quarter turn.
+func (d Direction) RotateCCW() Direction {
+	return d.Rotate(-1)
+}
+
+// Opposite returns the opposite direction.
+func (d Direction) Opposite() Direction {
+	return d.Rotate(4)
+}
+
+// Cardinal reports whether the direction is one of N, E, S or W.
+func (d Direction) Cardinal() bool {
+	return d < NoDirection && d%2 == 0
+}
+
+// Diagonal reports whether the direction is one of NE, SE, SW or NW.
+func (d Direction) Diagonal() bool {
+	return d < NoDirection && d%2 == 1
+}
+
+// tan(pi/8), used to split the plane into eight sectors of 45 degrees.
+const tanPi8 = 0.41421356237309503
+
+// Dir returns the direction whose sector of 45 degrees contains vector q-p,
+// that is, the approximate direction to go from p to q. It returns
+// NoDirection if p == q.
+func (p Point) Dir(q Point) Direction {
+	d := q.Sub(p)
+	if d.X == 0 && d.Y == 0 {
+		return NoDirection
+	}
+	ax, ay := float64(abs(d.X)), float64(abs(d.Y))
+	switch {
+	case ay <= tanPi8*ax:
+		if d.X > 0 {
+			return E
+		}
+		return W
+	case ax <= tanPi8*ay:
+		if d.Y > 0 {
+			return S
+		}
+		return N
+	case d.X > 0 && d.Y < 0:
+		return NE
+	case d.X > 0:
+		return SE
+	case d.Y > 0:
+		return SW
+	default:
+		return NW
+	}
+}
+
+// DirSet represents a set of directions, as a bitset where direction d
+// corresponds to bit 1<<d. It is suited for wall and autotiling logic, where
+// a cell's aspect depends on which of its neighbors satisfy some property.
+type DirSet uint8
+
+// Common direction sets.
+const (
+	CardinalDirs DirSet = 1<<N | 1<<E | 1<<S | 1<<W
+	DiagonalDirs DirSet = 1<<NE | 1<<SE | 1<<SW | 1<<NW
+	AllDirs      DirSet = CardinalDirs | DiagonalDirs
+)
+
+// Has reports whether direction d is in the set.
+func (s DirSet) Has(d Direction) bool {
+	return d < NoDirection && s&(1<<d) != 0
+}
+
+// Add returns the set with direction d added. NoDirection is ignored.
+func (s DirSet) Add(d Direction) DirSet {
+	if d >= NoDirection {
+		return s
+	}
+	return s | 1<<d
+}
+
+// Remove returns the set with direction d removed.
+func (s DirSet) Remove(d Direction) DirSet {
+	if d >= NoDirection {
+		return s
+	}
+	return s &^ (1 << d)
+}
+
+// Len returns the number of directions in the set.
+func (s DirSet) Len() int {
+	return bits.OnesCount8(uint8(s))
+}
+
+// Rotate returns the set with all its directions rotated clockwise by n
+// eighths of a turn.
+func (s DirSet) Rotate(n int) DirSet {
+	return DirSet(bits.RotateLeft8(uint8(s), (n%8+8)%8))
+}
@@ -0,0 +1,90 @@
+package grid
+
+import "testing"
+
+func TestDirectionDelta(t *testing.T) {
+	for d := N; d < NoDirection; d++ {
+		if d.Delta() != Moore[d] {
+			t.Errorf("bad delta for %v: %v", d, d.Delta())
+		}
+		if d.Opposite().Delta() != d.Delta().Mul(-1) {
+			t.Errorf("bad opposite for %v: %v", d, d.Opposite())
+		}
+		if d.RotateCW().RotateCCW() != d {
+			t.Errorf("bad rotation for %v", d)
+		}
+		if d.Rotate(-10) != d.Rotate(6) {
+			t.Errorf("bad negative rotation for %v", d)
+		}
+		if d.Cardinal() == d.Diagonal() {
+			t.Errorf("bad cardinal or diagonal for %v", d)
+		}
+		if (Point{}).Dir(d.Delta()) != d {
+			t.Errorf("bad Dir for %v: %v", d, (Point{}).Dir(d.Delta()))
+		}
+	}
+	if N.RotateCW() != NE || N.RotateCCW() != NW || E.Rotate(2) != S {
+		t.Errorf("bad rotations")
+	}
+	if NoDirection.Delta() != (Point{}) || NoDirection.Opposite() != NoDirection {
+		t.Errorf("bad NoDirection")
+	}
+	if NoDirection.Cardinal() || NoDirection.Diagonal() {
+		t.Errorf("bad NoDirection kind")
+	}
+	if SW.String() != "SW" {
+		t.Errorf("bad string: %s", SW)
+	}
+}
+
+func TestPointDir(t *testing.T) {
+	p := Point{5, 5}
+	tests := []struct {
+		q    Point
+		want Direction
+	}{
+		{Point{5, 5}, NoDirection},
+		{Point{15, 6}, E},
+		{Point{15, 8}, E},
+		{Point{15, 10}, SE},
+		{Point{4, -20}, N},
+		{Point{-5, -4}, NW},
+		{Point{2, 9}, SW},
+		{Point{0, 7}, W},
+	}
+	for _, tt := range tests {
+		if got := p.Dir(tt.q); got != tt.want {
+			t.Errorf("bad direction from %v to %v: %v instead of %v", p, tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestDirSet(t *testing.T) {
+	var s DirSet
+	s = s.Add(N).Add(E).Add(NoDirection)
+	if !s.Has(N) || !s.Has(E) || s.Has(S) || s.Has(NoDirection) {
+		t.Errorf("bad set: %08b", s)
+	}
+	if s.Len() != 2 {
+		t.Errorf("bad length: %d", s.Len())
+	}
+	s = s.Remove(N)
+	if s.Has(N) || s.Len() != 1 {
+		t.Errorf("bad remove: %08b", s)
+	}
+	if CardinalDirs.Len() != 4 || DiagonalDirs.Len() != 4 || AllDirs.Len() != 8 {
+		t.Errorf("bad predefined sets")
+	}
+	r := DirSet(0).Add(NW).Add(E).Rotate(2)
+	if !r.Has(NE) || !r.Has(S) || r.Len() != 2 {
+		t.Errorf("bad set rotation: %08b", r)
+	}
+	if CardinalDirs.Rotate(-1) != DiagonalDirs {
+		t.Errorf("bad negative set rotation")
+	}
+	for d := N; d < NoDirection; d++ {
+		if CardinalDirs.Has(d) != d.Cardinal() {
+			t.Errorf("bad cardinal set for %v", d)
+		}
+	}
+}
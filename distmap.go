@@ -0,0 +1,159 @@
+package grid
+
+import "math"
+
+// Unreachable is the distance value given to positions that cannot be
+// reached from any goal in a distance map. It is the maximum int value, so
+// it compares greater than any real distance, but no arithmetic should be
+// done with it.
+const Unreachable = math.MaxInt
+
+// DistanceMapper computes multi-source distance maps, also known as Dijkstra
+// maps: grids giving, for each position, the cost of the shortest path from
+// any of a set of goals. Moving from a position to a neighbor with a smaller
+// distance gets closer to the nearest goal.
+//
+// It also computes distance transforms, giving for each position the
+// distance to the nearest of a set of seeds, when all positions are
+// passable.
+//
+// A DistanceMapper keeps internal buffers across calls, so that recomputing
+// distance maps of the same size does not allocate. The zero value is ready
+// to use. A DistanceMapper should not be used concurrently.
+type DistanceMapper struct {
+	queue  []Point           // BFS queue
+	heap   minHeap[distNode] // Dijkstra priority queue
+	dist   []int             // distance transform working distances
+	rows   []int             // Euclidean transform nearest seed rows
+	env    []int             // Euclidean transform lower envelope parabolas
+	bounds []float64         // Euclidean transform lower envelope boundaries
+	feats  []Point           // raster transform nearest seeds
+}
+
+type distNode struct {
+	p Point
+	d int
+}
+
+// BFS computes into dst the unweighted distance map for the given goals,
+// where each move to a passable position costs 1, using breadth-first
+// search. Goals and positions are relative to dst, and goals out of dst's
+// range are ignored. Goals get distance 0, even if not passable, and
+// positions unreachable from any goal get distance Unreachable.
+func (dm *DistanceMapper) BFS(dst Grid[int], goals []Point, conn Connectivity, passable func(Point) bool) {
+	dst.Fill(Unreachable)
+	queue := dm.queue[:0]
+	for _, g := range goals {
+		if dst.Contains(g) {
+			dst.Set(g, 0)
+			queue = append(queue, g)
+		}
+	}
+	st := conn.Stencil()
+	for i := 0; i < len(queue); i++ {
+		p := queue[i]
+		d := dst.At(p) + 1
+		for _, off := range st {
+			q := p.Add(off)
+			if !dst.Contains(q) || dst.At(q) != Unreachable || !passable(q) {
+				continue
+			}
+			dst.Set(q, d)
+			queue = append(queue, q)
+		}
+	}
+	dm.queue = queue[:0]
+}
+
+// Dijkstra computes into dst the weighted distance map for the given goals,
+// using Dijkstra's algorithm. The cost function returns the cost of moving
+// into a position, which should be positive, and whether the position is
+// passable. Goals and positions are relative to dst, and goals out of dst's
+// range are ignored. Goals get distance 0, even if not passable, and
+// positions unreachable from any goal get distance Unreachable.
+//
+// If all passable positions have the same cost, BFS is faster.
+func (dm *DistanceMapper) Dijkstra(dst Grid[int], goals []Point, conn Connectivity, cost func(Point) (int, bool)) {
+	dst.Fill(Unreachable)
+	dm.heap = dm.heap[:0]
+	for _, g := range goals {
+		if dst.Contains(g) {
+			dst.Set(g, 0)
+			dm.heap.push(distNode{p: g})
+		}
+	}
+	st := conn.Stencil()
+	for len(dm.heap) > 0 {
+		n := dm.heap.pop()
+		if n.d > dst.At(n.p) {
+			// outdated entry
+			continue
+		}
+		for _, off := range st {
+			q := n.p.Add(off)
+			if !dst.Contains(q) {
+				continue
+			}
+			c, ok := cost(q)
+			if !ok {
+				continue
+			}
+			d := n.d + c
+			if d < dst.At(q) {
+				dst.Set(q, d)
+				dm.heap.push(distNode{p: q, d: d})
+			}
+		}
+	}
+}
+
+// less orders nodes by distance.
+func (n distNode) less(m distNode) bool {
+	return n.d < m.d
+}
+
+// minHeap is a binary min-heap of nodes ordered by their less method.
+type minHeap[N interface{ less(N) bool }] []N
+
+// push adds a node to the heap.
+func (h *minHeap[N]) push(n N) {
+	s := append(*h, n)
+	i := len(s) - 1
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !s[i].less(s[parent]) {
+			break
+		}
+		s[parent], s[i] = s[i], s[parent]
+		i = parent
+	}
+	*h = s
+}
+
+// pop removes and returns the minimal node from the heap, which should not
+// be empty.
+func (h *minHeap[N]) pop() N {
+	s := *h
+	n := s[0]
+	last := len(s) - 1
+	s[0] = s[last]
+	s = s[:last]
+	i := 0
+	for {
+		l := 2*i + 1
+		if l >= len(s) {
+			break
+		}
+		child := l
+		if r := l + 1; r < len(s) && s[r].less(s[l]) {
+			child = r
+		}
+		if !s[child].less(s[i]) {
+			break
+		}
+		s[i], s[child] = s[child], s[i]
+		i = child
+	}
+	*h = s
+	return n
+}
@@ -0,0 +1,102 @@
+package grid
+
+import "testing"
+
+func TestDistanceMapBFS(t *testing.T) {
+	gd := newMaze(
+		"......",
+		".####.",
+		"...#..",
+		"##.#.#",
+	)
+	passable := func(p Point) bool { return gd.At(p) != '#' }
+	dst := NewGrid[int](6, 4)
+	var dm DistanceMapper
+	dm.BFS(dst, []Point{{0, 0}}, Conn4, passable)
+	want := []int{
+		0, 1, 2, 3, 4, 5,
+		1, -1, -1, -1, -1, 6,
+		2, 3, 4, -1, 8, 7,
+		-1, -1, 5, -1, 9, -1,
+	}
+	dst.Iter(func(p Point, d int) {
+		w := want[p.Y*6+p.X]
+		if w == -1 {
+			w = Unreachable
+		}
+		if d != w {
+			t.Errorf("bad distance %d at %v (expected %d)", d, p, w)
+		}
+	})
+	dm.BFS(dst, []Point{{0, 0}, {5, 2}, {20, 20}}, Conn8, passable)
+	if dst.At(Point{4, 3}) != 1 || dst.At(Point{3, 0}) != 3 || dst.At(Point{2, 3}) != 3 {
+		t.Errorf("bad multi-source distances")
+	}
+}
+
+func TestDistanceMapDijkstra(t *testing.T) {
+	gd := NewGrid[int](30, 20)
+	for i := 0; i < 5; i++ {
+		gd.FillFunc(func(p Point) int { return randInt(4) })
+		goals := []Point{{randInt(30), randInt(20)}, {randInt(30), randInt(20)}}
+		var dm DistanceMapper
+		bfs := NewGrid[int](30, 20)
+		dijkstra := NewGrid[int](30, 20)
+		dm.BFS(bfs, goals, Conn8, func(p Point) bool { return gd.At(p) != 0 })
+		dm.Dijkstra(dijkstra, goals, Conn8, func(p Point) (int, bool) { return 1, gd.At(p) != 0 })
+		bfs.Iter(func(p Point, d int) {
+			if dijkstra.At(p) != d {
+				t.Errorf("BFS and Dijkstra differ at %v: %d vs %d", p, d, dijkstra.At(p))
+			}
+		})
+		dm.Dijkstra(dijkstra, goals, Conn4, func(p Point) (int, bool) { return gd.At(p), gd.At(p) != 0 })
+		dijkstra.Iter(func(p Point, d int) {
+			if d == 0 || d == Unreachable {
+				return
+			}
+			// d must be the minimum over neighbors plus the cost
+			best := Unreachable
+			for _, q := range dijkstra.Neighbors(nil, p, Cardinal) {
+				best = min(best, dijkstra.At(q))
+			}
+			if d != best+gd.At(p) {
+				t.Errorf("bad weighted distance %d at %v", d, p)
+			}
+		})
+	}
+}
+
+func TestDistanceMapAllocs(t *testing.T) {
+	dst := NewGrid[int](80, 24)
+	var dm DistanceMapper
+	goals := []Point{{3, 3}, {70, 20}}
+	cost := func(p Point) (int, bool) { return 1 + p.X%3, p.X%7 != 0 || p.Y%5 == 0 }
+	passable := func(p Point) bool { return p.X%7 != 0 || p.Y%5 == 0 }
+	dm.Dijkstra(dst, goals, Conn8, cost)
+	dm.BFS(dst, goals, Conn8, passable)
+	allocs := testing.AllocsPerRun(10, func() {
+		dm.Dijkstra(dst, goals, Conn8, cost)
+		dm.BFS(dst, goals, Conn8, passable)
+	})
+	if allocs != 0 {
+		t.Errorf("allocations when recomputing: %v", allocs)
+	}
+}
+
+func BenchmarkDistanceMapDijkstra(b *testing.B) {
+	dst := NewGrid[int](80, 24)
+	var dm DistanceMapper
+	goals := []Point{{3, 3}}
+	for i := 0; i < b.N; i++ {
+		dm.Dijkstra(dst, goals, Conn8, func(p Point) (int, bool) { return 1 + p.X%3, true })
+	}
+}
+
+func BenchmarkDistanceMapBFS(b *testing.B) {
+	dst := NewGrid[int](80, 24)
+	var dm DistanceMapper
+	goals := []Point{{3, 3}}
+	for i := 0; i < b.N; i++ {
+		dm.BFS(dst, goals, Conn8, func(p Point) bool { return true })
+	}
+}
@@ -0,0 +1,205 @@
+package grid
+
+import "math"
+
+// Euclidean computes into dst the exact Euclidean distance from each
+// position to the nearest seed, that is the nearest position whose cell in
+// seeds is true, using the linear-time algorithm of Felzenszwalb and
+// Huttenlocher. Positions are relative to seeds, and values for positions
+// out of dst are discarded. If there are no seeds, all distances are
+// +Inf.
+//
+// All positions are considered passable. For such distance transforms,
+// Euclidean, Chamfer and Manhattan are faster than a BFS or Dijkstra map with
+// seeds as goals, as they do not need any queue.
+//
+// If nearest is not the zero grid, it is filled too with the position of a
+// nearest seed for each position (feature transform), or with (-1, -1) if
+// there are no seeds.
+func (dm *DistanceMapper) Euclidean(dst Grid[float64], seeds Grid[bool], nearest Grid[Point]) {
+	max := seeds.Size()
+	w, h := max.X, max.Y
+	n := w * h
+	dm.dist = growInts(dm.dist, n)
+	dm.rows = growInts(dm.rows, n)
+	// Vertical pass: squared distance to the nearest seed in the same
+	// column, and its row.
+	for x := 0; x < w; x++ {
+		last := -1
+		for y := 0; y < h; y++ {
+			if seeds.At(Point{x, y}) {
+				last = y
+			}
+			dm.rows[y*w+x] = last
+		}
+		last = -1
+		for y := h - 1; y >= 0; y-- {
+			i := y*w + x
+			if seeds.At(Point{x, y}) {
+				last = y
+			}
+			if last >= 0 && (dm.rows[i] < 0 || last-y < y-dm.rows[i]) {
+				dm.rows[i] = last
+			}
+			if dm.rows[i] < 0 {
+				dm.dist[i] = Unreachable
+			} else {
+				dy := dm.rows[i] - y
+				dm.dist[i] = dy * dy
+			}
+		}
+	}
+	// Horizontal pass: lower envelope of the parabolas rooted at each
+	// column with a finite vertical distance.
+	dm.env = growInts(dm.env, w)
+	if cap(dm.bounds) < w+1 {
+		dm.bounds = make([]float64, w+1)
+	}
+	v, z := dm.env[:w], dm.bounds[:w+1]
+	for y := 0; y < h; y++ {
+		g := dm.dist[y*w : (y+1)*w]
+		k := -1
+		for q := 0; q < w; q++ {
+			if g[q] == Unreachable {
+				continue
+			}
+			var s float64
+			for k >= 0 {
+				p := v[k]
+				s = float64(g[q]+q*q-g[p]-p*p) / float64(2*(q-p))
+				if s > z[k] {
+					break
+				}
+				k--
+			}
+			k++
+			v[k] = q
+			if k == 0 {
+				z[k] = math.Inf(-1)
+			} else {
+				z[k] = s
+			}
+			z[k+1] = math.Inf(1)
+		}
+		for x, j := 0, 0; x < w; x++ {
+			p := Point{x, y}
+			if k < 0 {
+				dst.Set(p, math.Inf(1))
+				nearest.Set(p, Point{-1, -1})
+				continue
+			}
+			for z[j+1] < float64(x) {
+				j++
+			}
+			q := v[j]
+			dx := x - q
+			dst.Set(p, math.Sqrt(float64(dx*dx+g[q])))
+			nearest.Set(p, Point{q, dm.rows[y*w+q]})
+		}
+	}
+}
+
+// Chamfer computes into dst an approximation of the Euclidean distance from
+// each position to the nearest seed, that is the nearest position whose cell
+// in seeds is true, using the 3-4 chamfer metric: cardinal moves cost 3 and
+// diagonal moves cost 4, so distances are in units of a third of a cell.
+// Positions are relative to seeds, and values for positions out of dst are
+// discarded. All positions are considered passable. If there are no seeds,
+// all distances are Unreachable.
+//
+// If nearest is not the zero grid, it is filled too with the position of the
+// seed from which the distance of each position was propagated, or with
+// (-1, -1) if there are no seeds.
+func (dm *DistanceMapper) Chamfer(dst Grid[int], seeds Grid[bool], nearest Grid[Point]) {
+	dm.rasterTransform(dst, seeds, nearest, 3, 4)
+}
+
+// Manhattan computes into dst the exact Manhattan distance from each
+// position to the nearest seed, that is the nearest position whose cell in
+// seeds is true. Positions are relative to seeds, and values for positions
+// out of dst are discarded. All positions are considered passable. If there
+// are no seeds, all distances are Unreachable.
+//
+// If nearest is not the zero grid, it is filled too with the position of a
+// nearest seed for each position, or with (-1, -1) if there are no seeds.
+func (dm *DistanceMapper) Manhattan(dst Grid[int], seeds Grid[bool], nearest Grid[Point]) {
+	dm.rasterTransform(dst, seeds, nearest, 1, 0)
+}
+
+// rasterTransform computes a distance transform with a forward and a
+// backward raster scan, using cost a for cardinal moves, and cost b for
+// diagonal moves, or no diagonal moves if b is zero.
+func (dm *DistanceMapper) rasterTransform(dst Grid[int], seeds Grid[bool], nearest Grid[Point], a, b int) {
+	max := seeds.Size()
+	w, h := max.X, max.Y
+	n := w * h
+	dm.dist = growInts(dm.dist, n)
+	if cap(dm.feats) < n {
+		dm.feats = make([]Point, n)
+	}
+	dist, feats := dm.dist[:n], dm.feats[:n]
+	for i := range dist {
+		p := Point{i % w, i / w}
+		if seeds.At(p) {
+			dist[i] = 0
+			feats[i] = p
+		} else {
+			dist[i] = Unreachable
+			feats[i] = Point{-1, -1}
+		}
+	}
+	// already visited neighbors in forward raster order, with their cost
+	type mask struct {
+		d Point
+		c int
+	}
+	masks := [4]mask{{Point{-1, 0}, a}, {Point{0, -1}, a}, {Point{-1, -1}, b}, {Point{1, -1}, b}}
+	fwd := masks[:2]
+	if b > 0 {
+		fwd = masks[:]
+	}
+	relax := func(x, y int, back bool) {
+		i := y*w + x
+		for _, m := range fwd {
+			d := m.d
+			if back {
+				d = d.Neg()
+			}
+			qx, qy := x+d.X, y+d.Y
+			if qx < 0 || qx >= w || qy < 0 || qy >= h {
+				continue
+			}
+			j := qy*w + qx
+			if dist[j] == Unreachable {
+				continue
+			}
+			if nd := dist[j] + m.c; nd < dist[i] {
+				dist[i] = nd
+				feats[i] = feats[j]
+			}
+		}
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			relax(x, y, false)
+		}
+	}
+	for y := h - 1; y >= 0; y-- {
+		for x := w - 1; x >= 0; x-- {
+			relax(x, y, true)
+		}
+	}
+	for i, d := range dist {
+		p := Point{i % w, i / w}
+		dst.Set(p, d)
+		nearest.Set(p, feats[i])
+	}
+}
+
+// growInts returns a slice of length n, reusing buf if possible.
+func growInts(buf []int, n int) []int {
+	if cap(buf) < n {
+		return make([]int, n)
+	}
+	return buf[:n]
+}
@@ -0,0 +1,83 @@
+package grid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceTransforms(t *testing.T) {
+	var dm DistanceMapper
+	seeds := NewGrid[bool](17, 11)
+	edt := NewGrid[float64](17, 11)
+	cdt := NewGrid[int](17, 11)
+	mdt := NewGrid[int](17, 11)
+	nearest := NewGrid[Point](17, 11)
+	var pts []Point
+	for n := 0; n < 30; n++ {
+		pts = pts[:0]
+		seeds.Fill(false)
+		for i := randInt(6); i >= 0; i-- {
+			p := Point{randInt(17), randInt(11)}
+			seeds.Set(p, true)
+			pts = append(pts, p)
+		}
+		dm.Euclidean(edt, seeds, nearest)
+		for p, d := range edt.All() {
+			best := math.Inf(1)
+			for _, q := range pts {
+				best = math.Min(best, p.Euclidean(q))
+			}
+			q := nearest.At(p)
+			if math.Abs(d-best) > 1e-9 || !seeds.At(q) || math.Abs(p.Euclidean(q)-best) > 1e-9 {
+				t.Fatalf("bad Euclidean distance at %v: %v (nearest %v) vs %v", p, d, q, best)
+			}
+		}
+		dm.Manhattan(mdt, seeds, nearest)
+		for p, d := range mdt.All() {
+			best := Unreachable
+			for _, q := range pts {
+				best = min(best, p.Manhattan(q))
+			}
+			if q := nearest.At(p); d != best || !seeds.At(q) || p.Manhattan(q) != best {
+				t.Fatalf("bad Manhattan distance at %v: %v (nearest %v) vs %v", p, d, q, best)
+			}
+		}
+		dm.Chamfer(cdt, seeds, nearest)
+		for p, d := range cdt.All() {
+			best := Unreachable
+			for _, q := range pts {
+				dq := p.Sub(q).Abs()
+				best = min(best, 4*min(dq.X, dq.Y)+3*(max(dq.X, dq.Y)-min(dq.X, dq.Y)))
+			}
+			if d != best || !seeds.At(nearest.At(p)) {
+				t.Fatalf("bad chamfer distance at %v: %v vs %v", p, d, best)
+			}
+		}
+	}
+	seeds.Fill(false)
+	dm.Euclidean(edt, seeds, Grid[Point]{})
+	dm.Manhattan(mdt, seeds, nearest)
+	if !math.IsInf(edt.At(Point{3, 3}), 1) || mdt.At(Point{3, 3}) != Unreachable || nearest.At(Point{3, 3}) != (Point{-1, -1}) {
+		t.Errorf("bad distances without seeds")
+	}
+}
+
+func TestDistanceTransformsAllocs(t *testing.T) {
+	var dm DistanceMapper
+	seeds := NewGrid[bool](80, 24)
+	seeds.Set(Point{3, 3}, true)
+	seeds.Set(Point{70, 20}, true)
+	edt := NewGrid[float64](80, 24)
+	dst := NewGrid[int](80, 24)
+	nearest := NewGrid[Point](80, 24)
+	dm.Euclidean(edt, seeds, nearest)
+	dm.Chamfer(dst, seeds, nearest)
+	allocs := testing.AllocsPerRun(10, func() {
+		dm.Euclidean(edt, seeds, nearest)
+		dm.Chamfer(dst, seeds, nearest)
+		dm.Manhattan(dst, seeds, nearest)
+	})
+	if allocs != 0 {
+		t.Errorf("allocations when recomputing: %v", allocs)
+	}
+}
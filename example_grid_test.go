@@ -69,3 +69,29 @@ func ExampleGridIterator() {
 	// abcdefghijklmnopqrstuvwxyz
 	// ABCDEFGHIJKLMNOPQRSTUVWXYZ
 }
+
+func ExampleGrid_All() {
+	// Create a new 5x3 grid of runes and fill it with dots.
+	gd := grid.NewGrid[rune](5, 3)
+	gd.Fill('.')
+	gd.Set(grid.Point{3, 1}, '@')
+	// Find the first non-dot cell.
+	for p, r := range gd.All() {
+		if r != '.' {
+			fmt.Printf("%c at %v\n", r, p)
+			break
+		}
+	}
+	// Print the grid line by line.
+	for _, line := range gd.Rows() {
+		for r := range line.Values() {
+			fmt.Printf("%c", r)
+		}
+		fmt.Print("\n")
+	}
+	// Output:
+	// @ at (3,1)
+	// .....
+	// ...@.
+	// .....
+}
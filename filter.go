@@ -0,0 +1,225 @@
+package grid
+
+import "math"
+
+// Number is a constraint for numeric cell types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Predefined 3x3 kernels for use with Filter.Convolve. They should not be
+// modified.
+var (
+	// SobelX computes the horizontal derivative, positive when values
+	// increase toward the right.
+	SobelX = NewGridFromSlice([]float64{
+		-1, 0, 1,
+		-2, 0, 2,
+		-1, 0, 1,
+	}, 3)
+	// SobelY computes the vertical derivative, positive when values
+	// increase toward the bottom.
+	SobelY = NewGridFromSlice([]float64{
+		-1, -2, -1,
+		0, 0, 0,
+		1, 2, 1,
+	}, 3)
+	// Laplacian computes the discrete Laplacian using the 4-connected
+	// neighborhood.
+	Laplacian = NewGridFromSlice([]float64{
+		0, 1, 0,
+		1, -4, 1,
+		0, 1, 0,
+	}, 3)
+)
+
+// BoxKernel returns a new one-dimensional kernel of length 2r+1 with equal
+// weights summing to 1, for use with Filter.ConvolveSeparable. If r is not
+// positive, it returns the identity kernel [1].
+func BoxKernel(r int) []float64 {
+	return boxKernel(nil, r)
+}
+
+// boxKernel appends to buf[:0] the kernel returned by BoxKernel(r).
+func boxKernel(buf []float64, r int) []float64 {
+	r = max(r, 0)
+	k := append(buf[:0], make([]float64, 2*r+1)...)
+	for i := range k {
+		k[i] = 1 / float64(len(k))
+	}
+	return k
+}
+
+// GaussianKernel returns a new one-dimensional Gaussian kernel of standard
+// deviation sigma, truncated at 3*sigma and normalized so that its weights
+// sum to 1, for use with Filter.ConvolveSeparable. If sigma is not positive,
+// it returns the identity kernel [1].
+func GaussianKernel(sigma float64) []float64 {
+	return gaussianKernel(nil, sigma)
+}
+
+// gaussianKernel appends to buf[:0] the kernel returned by
+// GaussianKernel(sigma).
+func gaussianKernel(buf []float64, sigma float64) []float64 {
+	if !(sigma > 0) {
+		return append(buf[:0], 1)
+	}
+	r := int(math.Ceil(3 * sigma))
+	k := append(buf[:0], make([]float64, 2*r+1)...)
+	sum := 0.0
+	for i := range k {
+		x := float64(i - r)
+		k[i] = math.Exp(-x * x / (2 * sigma * sigma))
+		sum += k[i]
+	}
+	for i := range k {
+		k[i] /= sum
+	}
+	return k
+}
+
+// Filter applies convolution kernels to numeric grids, for example to blur
+// heightmaps, or to detect edges. Kernels are not flipped, so that the
+// result at position p is the sum of k(d)*src(p+d-c) for all positions d of
+// kernel k, where c is the kernel's center position, k.Size().Div(2).
+//
+// The source is read into an internal buffer before the destination is
+// written, so that the destination and source grids may overlap. For
+// integer cell types, results are rounded to the nearest integer.
+//
+// A Filter keeps internal buffers across calls, so that filtering grids of
+// the same size does not allocate. The zero value is ready to use, treating
+// positions out of the source grid as zero. A Filter should not be used
+// concurrently.
+type Filter[T Number] struct {
+	// Edge determines the value of positions out of the source grid.
+	// EdgeBounded behaves as EdgeConstant with a zero Border.
+	Edge EdgeMode
+	// Border is the value of positions out of the source grid for
+	// EdgeConstant.
+	Border T
+
+	src    []float64 // source values
+	tmp    []float64 // separable convolution intermediate values
+	kernel []float64 // blur kernel
+}
+
+// Convolve computes into dst the convolution of src with kernel k. Only the
+// positions in both dst and src are written.
+func (f *Filter[T]) Convolve(dst, src Grid[T], k Grid[float64]) {
+	w, h := f.load(src)
+	max := dst.Range().Intersect(src.Range()).Size()
+	c := k.Size().Div(2)
+	for y := 0; y < max.Y; y++ {
+		for x := 0; x < max.X; x++ {
+			sum := 0.0
+			k.Iter(func(d Point, kv float64) {
+				sum += kv * f.sample(x+d.X-c.X, y+d.Y-c.Y, w, h)
+			})
+			dst.Set(Point{x, y}, f.value(sum))
+		}
+	}
+}
+
+// ConvolveSeparable computes into dst the convolution of src with the
+// separable kernel given by the outer product of kernels ky and kx, that is
+// kernel k such that k(x,y) = kx[x]*ky[y]. It first convolves lines with
+// kx, and then columns with ky, which is faster than Convolve for big
+// kernels. Only the positions in both dst and src are written.
+func (f *Filter[T]) ConvolveSeparable(dst, src Grid[T], kx, ky []float64) {
+	w, h := f.load(src)
+	if cap(f.tmp) < w*h {
+		f.tmp = make([]float64, w*h)
+	}
+	tmp := f.tmp[:w*h]
+	cx, cy := len(kx)/2, len(ky)/2
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			sum := 0.0
+			for i, kv := range kx {
+				sum += kv * f.sample(x+i-cx, y, w, h)
+			}
+			tmp[y*w+x] = sum
+		}
+	}
+	// The horizontal pass is linear, so out-of-grid positions of the
+	// intermediate result are obtained from the out-of-grid source values.
+	border := 0.0
+	if f.Edge == EdgeConstant {
+		for _, kv := range kx {
+			border += kv * float64(f.Border)
+		}
+	}
+	max := dst.Range().Intersect(src.Range()).Size()
+	for y := 0; y < max.Y; y++ {
+		for x := 0; x < max.X; x++ {
+			sum := 0.0
+			for i, kv := range ky {
+				qy, ok := f.Edge.coord(y+i-cy, h)
+				if ok {
+					sum += kv * tmp[qy*w+x]
+				} else {
+					sum += kv * border
+				}
+			}
+			dst.Set(Point{x, y}, f.value(sum))
+		}
+	}
+}
+
+// BoxBlur computes into dst the average of src values over a square of
+// radius r around each position, as given by BoxKernel: it merely copies src
+// if r is not positive. Only the positions in both dst and src are written.
+func (f *Filter[T]) BoxBlur(dst, src Grid[T], r int) {
+	f.kernel = boxKernel(f.kernel, r)
+	f.ConvolveSeparable(dst, src, f.kernel, f.kernel)
+}
+
+// GaussianBlur computes into dst the convolution of src with a Gaussian
+// kernel of standard deviation sigma, as given by GaussianKernel: it merely
+// copies src if sigma is not positive. Only the positions in both dst and src
+// are written.
+func (f *Filter[T]) GaussianBlur(dst, src Grid[T], sigma float64) {
+	f.kernel = gaussianKernel(f.kernel, sigma)
+	f.ConvolveSeparable(dst, src, f.kernel, f.kernel)
+}
+
+// load reads the values of src into the source buffer, and returns the size
+// of src.
+func (f *Filter[T]) load(src Grid[T]) (w, h int) {
+	max := src.Size()
+	w, h = max.X, max.Y
+	if cap(f.src) < w*h {
+		f.src = make([]float64, w*h)
+	}
+	f.src = f.src[:w*h]
+	src.Iter(func(p Point, c T) {
+		f.src[p.Y*w+p.X] = float64(c)
+	})
+	return w, h
+}
+
+// sample returns the source value at position (x, y), taking into account
+// the edge mode.
+func (f *Filter[T]) sample(x, y, w, h int) float64 {
+	qx, okx := f.Edge.coord(x, w)
+	qy, oky := f.Edge.coord(y, h)
+	if !okx || !oky {
+		if f.Edge == EdgeConstant {
+			return float64(f.Border)
+		}
+		return 0
+	}
+	return f.src[qy*w+qx]
+}
+
+// value converts a result to the cell type, rounding it for integer types.
+func (f *Filter[T]) value(v float64) T {
+	half := 0.5
+	if T(half) == 0 {
+		v = math.Round(v)
+	}
+	return T(v)
+}
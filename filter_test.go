@@ -0,0 +1,139 @@
+package grid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEdgeModeCoord(t *testing.T) {
+	for _, tc := range []struct {
+		mode EdgeMode
+		i, j int
+		ok   bool
+	}{
+		{EdgeBounded, 2, 2, true},
+		{EdgeBounded, -1, -1, false},
+		{EdgeConstant, 5, 5, false},
+		{EdgeWrap, -1, 4, true},
+		{EdgeWrap, 11, 1, true},
+		{EdgeClamp, -3, 0, true},
+		{EdgeClamp, 7, 4, true},
+		{EdgeMirror, -1, 0, true},
+		{EdgeMirror, -2, 1, true},
+		{EdgeMirror, 5, 4, true},
+		{EdgeMirror, 6, 3, true},
+		{EdgeMirror, 10, 0, true},
+	} {
+		if j, ok := tc.mode.coord(tc.i, 5); j != tc.j || ok != tc.ok {
+			t.Errorf("bad coord for mode %d and %d: %d %v", tc.mode, tc.i, j, ok)
+		}
+	}
+}
+
+func TestFilterSeparable(t *testing.T) {
+	src := NewGrid[float64](9, 7)
+	src.Map(func(Point, float64) float64 { return float64(randInt(100)) })
+	kx, ky := []float64{1, 2, 3}, []float64{0.5, -1, 2, 1, 4}
+	k := NewGrid[float64](3, 5)
+	k.Map(func(p Point, _ float64) float64 { return kx[p.X] * ky[p.Y] })
+	dst1 := NewGrid[float64](9, 7)
+	dst2 := NewGrid[float64](9, 7)
+	for _, mode := range []EdgeMode{EdgeBounded, EdgeWrap, EdgeConstant, EdgeClamp, EdgeMirror} {
+		f := Filter[float64]{Edge: mode, Border: 3}
+		f.Convolve(dst1, src, k)
+		f.ConvolveSeparable(dst2, src, kx, ky)
+		for p, v := range dst1.All() {
+			if math.Abs(v-dst2.At(p)) > 1e-9 {
+				t.Fatalf("mode %d: separable mismatch at %v: %v vs %v", mode, p, v, dst2.At(p))
+			}
+		}
+	}
+}
+
+func TestFilterBlur(t *testing.T) {
+	src := NewGrid[int](6, 5)
+	src.Fill(10)
+	dst := NewGrid[int](6, 5)
+	var f Filter[int]
+	f.Edge = EdgeClamp
+	f.BoxBlur(dst, src, 1)
+	for p, v := range dst.All() {
+		if v != 10 {
+			t.Errorf("bad clamped blur at %v: %d", p, v)
+		}
+	}
+	f.Edge = EdgeBounded
+	f.BoxBlur(dst, src, 1)
+	if dst.At(Point{0, 0}) != 4 || dst.At(Point{1, 0}) != 7 || dst.At(Point{2, 2}) != 10 {
+		t.Errorf("bad zero border blur: %d %d", dst.At(Point{0, 0}), dst.At(Point{1, 0}))
+	}
+	gauss := GaussianKernel(1.5)
+	sum := 0.0
+	for _, v := range gauss {
+		sum += v
+	}
+	if len(gauss) != 11 || math.Abs(sum-1) > 1e-9 || gauss[5] <= gauss[4] || gauss[4] != gauss[6] {
+		t.Errorf("bad gaussian kernel: %v", gauss)
+	}
+	f.Edge = EdgeWrap
+	f.GaussianBlur(dst, src, 1.5)
+	for p, v := range dst.All() {
+		if v != 10 {
+			t.Errorf("bad wrapped gaussian blur at %v: %d", p, v)
+		}
+	}
+	for _, k := range [][]float64{BoxKernel(0), BoxKernel(-2), GaussianKernel(0), GaussianKernel(-1)} {
+		if len(k) != 1 || k[0] != 1 {
+			t.Errorf("bad identity kernel: %v", k)
+		}
+	}
+	src.Map(func(p Point, _ int) int { return p.X + 10*p.Y })
+	f.BoxBlur(dst, src, -1)
+	if !equalGrids(dst, src) {
+		t.Errorf("bad box blur with negative radius")
+	}
+	f.GaussianBlur(dst, src, 0)
+	if !equalGrids(dst, src) {
+		t.Errorf("bad gaussian blur with zero sigma")
+	}
+	allocs := testing.AllocsPerRun(10, func() {
+		f.GaussianBlur(dst, src, 1.5)
+		f.BoxBlur(dst, src, 2)
+	})
+	if allocs != 0 {
+		t.Errorf("allocations when blurring again: %v", allocs)
+	}
+}
+
+func TestFilterKernels(t *testing.T) {
+	// horizontal ramp
+	src := NewGrid[float64](5, 5)
+	src.Map(func(p Point, _ float64) float64 { return float64(2 * p.X) })
+	dst := NewGrid[float64](5, 5)
+	f := Filter[float64]{Edge: EdgeClamp}
+	f.Convolve(dst, src, SobelX)
+	if dst.At(Point{2, 2}) != 16 || dst.At(Point{0, 2}) != 8 {
+		t.Errorf("bad sobel x: %v %v", dst.At(Point{2, 2}), dst.At(Point{0, 2}))
+	}
+	f.Convolve(dst, src, SobelY)
+	if dst.At(Point{2, 2}) != 0 {
+		t.Errorf("bad sobel y: %v", dst.At(Point{2, 2}))
+	}
+	f.Convolve(dst, src, Laplacian)
+	if dst.At(Point{2, 2}) != 0 || dst.At(Point{4, 2}) != -2 {
+		t.Errorf("bad laplacian: %v %v", dst.At(Point{2, 2}), dst.At(Point{4, 2}))
+	}
+	// in-place filtering into a slice of a bigger grid
+	big := NewGrid[float64](9, 9)
+	sub := big.Slice(NewRange(2, 2, 7, 7))
+	sub.Copy(src)
+	f.Convolve(sub, sub, NewGridFromSlice([]float64{1, 0, 0}, 3))
+	for p, v := range sub.All() {
+		if want := float64(2 * max(p.X-1, 0)); v != want {
+			t.Errorf("bad in-place shift at %v: %v vs %v", p, v, want)
+		}
+	}
+	if big.At(Point{1, 2}) != 0 || big.At(Point{7, 2}) != 0 {
+		t.Errorf("positions out of the destination slice were modified")
+	}
+}
@@ -0,0 +1,128 @@
+package grid
+
+// Connectivity represents which neighbors are considered adjacent to a
+// position in region-based algorithms.
+type Connectivity int
+
+// Available connectivities.
+const (
+	// Conn4 considers only the four cardinal neighbors.
+	Conn4 Connectivity = 4
+	// Conn8 considers the cardinal and diagonal neighbors.
+	Conn8 Connectivity = 8
+)
+
+// Stencil returns the neighborhood corresponding to the connectivity, that
+// is Cardinal for Conn4, and Moore for Conn8.
+func (conn Connectivity) Stencil() Stencil {
+	if conn == Conn8 {
+		return Moore
+	}
+	return Cardinal
+}
+
+// FloodFill sets to value c all the cells of the region of positions
+// connected to start, using the given connectivity, whose cells satisfy
+// match. Nothing happens if start is out of the grid or its cell does not
+// match.
+//
+// It uses an explicit stack of horizontal spans, so it works for big grids
+// without recursion nor per-position allocations. If match(c) is false, it
+// does not allocate memory proportional to the grid size either.
+func FloodFill[T any](gd Grid[T], start Point, conn Connectivity, match func(T) bool, c T) {
+	if !match(c) {
+		floodSpans(start, conn, func(p Point) bool {
+			return gd.Contains(p) && match(gd.At(p))
+		}, func(y, x0, x1 int) {
+			gd.fillSpan(y, x0, x1, c)
+		})
+		return
+	}
+	// c matches, so cells that were already filled have to be tracked
+	// separately.
+	region := FloodRegion(gd, start, conn, match)
+	region.Iter(func(p Point, in bool) {
+		if in {
+			gd.Set(p, c)
+		}
+	})
+}
+
+// FloodRegion returns a boolean grid of same size as gd that marks the
+// positions of the region connected to start, using the given connectivity,
+// whose cells satisfy match. It is all false if start is out of the grid or
+// its cell does not match.
+func FloodRegion[T any](gd Grid[T], start Point, conn Connectivity, match func(T) bool) Grid[bool] {
+	max := gd.Size()
+	region := NewGrid[bool](max.X, max.Y)
+	floodSpans(start, conn, func(p Point) bool {
+		return gd.Contains(p) && !region.At(p) && match(gd.At(p))
+	}, func(y, x0, x1 int) {
+		region.fillSpan(y, x0, x1, true)
+	})
+	return region
+}
+
+// FloodPoints appends to buf[:0] the positions of the region connected to
+// start, using the given connectivity, whose cells satisfy match, and returns
+// the updated slice. Positions are appended span by span, in the order they
+// are found.
+func FloodPoints[T any](buf []Point, gd Grid[T], start Point, conn Connectivity, match func(T) bool) []Point {
+	buf = buf[:0]
+	max := gd.Size()
+	visited := NewGrid[bool](max.X, max.Y)
+	floodSpans(start, conn, func(p Point) bool {
+		return gd.Contains(p) && !visited.At(p) && match(gd.At(p))
+	}, func(y, x0, x1 int) {
+		visited.fillSpan(y, x0, x1, true)
+		for x := x0; x < x1; x++ {
+			buf = append(buf, Point{x, y})
+		}
+	})
+	return buf
+}
+
+// floodSpans performs a scanline flood fill from start. The inside function
+// reports whether a position belongs to the region and has not been marked
+// yet, and mark is called for each maximal horizontal span of the region,
+// from column x0 (included) to x1 (excluded), after which inside should
+// return false for the span's positions.
+func floodSpans(start Point, conn Connectivity, inside func(Point) bool, mark func(y, x0, x1 int)) {
+	if !inside(start) {
+		return
+	}
+	d := 0
+	if conn == Conn8 {
+		d = 1
+	}
+	stack := []Point{start}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !inside(p) {
+			continue
+		}
+		x0, x1 := p.X, p.X+1
+		for inside(Point{x0 - 1, p.Y}) {
+			x0--
+		}
+		for inside(Point{x1, p.Y}) {
+			x1++
+		}
+		mark(p.Y, x0, x1)
+		for _, y := range [2]int{p.Y - 1, p.Y + 1} {
+			// push one seed per run of inside positions
+			run := false
+			for x := x0 - d; x < x1+d; x++ {
+				if inside(Point{x, y}) {
+					if !run {
+						stack = append(stack, Point{x, y})
+						run = true
+					}
+				} else {
+					run = false
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package grid
+
+import "testing"
+
+// newMaze returns a grid from the given lines, where '#' represents walls.
+func newMaze(lines ...string) Grid[rune] {
+	gd := NewGrid[rune](len(lines[0]), len(lines))
+	gd.FillFunc(func(p Point) rune {
+		return rune(lines[p.Y][p.X])
+	})
+	return gd
+}
+
+func isFloor(r rune) bool { return r == '.' }
+
+func TestFloodFill(t *testing.T) {
+	gd := newMaze(
+		"..#.....",
+		"..#.####",
+		"###.#...",
+		"....#.#.",
+		".#.#..#.",
+	)
+	FloodFill(gd, Point{0, 0}, Conn4, isFloor, 'x')
+	n := 0
+	gd.Iter(func(p Point, r rune) {
+		if r == 'x' {
+			n++
+			if !p.In(NewRange(0, 0, 2, 2)) {
+				t.Errorf("bad filled position %v", p)
+			}
+		}
+	})
+	if n != 4 {
+		t.Errorf("bad number of filled cells: %d", n)
+	}
+	FloodFill(gd, Point{0, 3}, Conn4, isFloor, 'y')
+	if gd.At(Point{2, 4}) != 'y' || gd.At(Point{7, 0}) != 'y' || gd.At(Point{4, 4}) == 'y' {
+		t.Errorf("bad 4-connected fill")
+	}
+	FloodFill(gd, Point{0, 3}, Conn8, func(r rune) bool { return r == 'y' }, 'z')
+	if gd.At(Point{2, 4}) != 'z' || gd.At(Point{4, 4}) != '.' {
+		t.Errorf("bad 8-connected fill")
+	}
+	FloodFill(gd, Point{4, 4}, Conn8, isFloor, 'w')
+	if gd.At(Point{7, 4}) != 'w' || gd.At(Point{5, 2}) != 'w' || gd.At(Point{3, 0}) != 'z' {
+		t.Errorf("bad 8-connected fill")
+	}
+	// filling with a matching value must terminate
+	FloodFill(gd, Point{0, 3}, Conn8, func(r rune) bool { return r != '#' }, '.')
+	if gd.At(Point{3, 0}) != '.' || gd.At(Point{7, 4}) != '.' || gd.At(Point{0, 0}) != 'x' {
+		t.Errorf("bad fill with matching value")
+	}
+	FloodFill(gd, Point{-1, 0}, Conn4, isFloor, 'x') // does nothing
+}
+
+func TestFloodRegion(t *testing.T) {
+	gd := newMaze(
+		"#####",
+		"#..##",
+		"##.#.",
+		"#####",
+	)
+	slice := gd.Slice(NewRange(1, 1, 5, 3))
+	region := FloodRegion(slice, Point{0, 0}, Conn8, isFloor)
+	if region.Size() != slice.Size() {
+		t.Errorf("bad region size: %v", region.Size())
+	}
+	n := 0
+	region.Iter(func(p Point, in bool) {
+		if in {
+			n++
+			if slice.At(p) != '.' {
+				t.Errorf("bad region position %v", p)
+			}
+		}
+	})
+	if n != 3 {
+		t.Errorf("bad region count: %d", n)
+	}
+	ps := FloodPoints(nil, slice, Point{1, 1}, Conn4, isFloor)
+	if len(ps) != 3 {
+		t.Errorf("bad flood points: %v", ps)
+	}
+	ps = FloodPoints(ps, slice, Point{2, 0}, Conn4, isFloor)
+	if len(ps) != 0 {
+		t.Errorf("non empty flood points from wall: %v", ps)
+	}
+}
+
+func TestFloodFillLarge(t *testing.T) {
+	// a serpentine corridor that would overflow a naive recursive
+	// implementation
+	gd := NewGrid[bool](300, 300)
+	gd.FillFunc(func(p Point) bool {
+		switch {
+		case p.Y%2 == 0:
+			return true
+		case p.Y%4 == 1:
+			return p.X == 299
+		default:
+			return p.X == 0
+		}
+	})
+	ps := FloodPoints(nil, gd, Point{}, Conn4, func(b bool) bool { return b })
+	if len(ps) != 150*300+150 {
+		t.Errorf("bad number of points: %d", len(ps))
+	}
+}
+
+func BenchmarkFloodFill(b *testing.B) {
+	gd := NewGrid[int](1000, 1000)
+	for i := 0; i < b.N; i++ {
+		FloodFill(gd, Point{500, 500}, Conn4, func(c int) bool { return c == i }, i+1)
+	}
+}
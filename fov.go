@@ -0,0 +1,184 @@
+package grid
+
+// RadiusShape represents the shape of the area within a given radius of a
+// position.
+type RadiusShape int
+
+// Available radius shapes.
+const (
+	// RadiusSquare includes positions at Chebyshev distance at most r.
+	RadiusSquare RadiusShape = iota
+	// RadiusDiamond includes positions at Manhattan distance at most r.
+	RadiusDiamond
+	// RadiusCircle includes positions of the disk of radius r, as given
+	// by Disk.
+	RadiusCircle
+)
+
+// FOV computes fields of view using symmetric shadowcasting: a position is
+// visible from an origin if a line from the center of the origin to the
+// center of the position does not cross any light-blocking position. For
+// transparent positions, visibility is symmetric: if q is visible from p,
+// then p is visible from q. Light-blocking positions are visible if some
+// part of them is lit, giving nice-looking walls.
+//
+// An FOV keeps internal buffers across calls, so that computing fields of
+// view with the same radius does not allocate. The zero value is ready to
+// use, with a square radius shape and lit walls. An FOV should not be used
+// concurrently.
+type FOV struct {
+	// Shape determines which positions are within radius.
+	Shape RadiusShape
+	// ExcludeWalls, if true, makes only transparent positions visible:
+	// light-blocking positions are never marked visible.
+	ExcludeWalls bool
+
+	rows []fovRow // stack of rows to scan
+	half []int    // circle half-widths for radius len(half)-1
+	diag []uint32 // generation at which diagonal positions were visited
+	gen  uint32   // current computation generation
+	r    int      // current radius
+}
+
+// fovRow represents the part of a row at a given depth of a quadrant that is
+// between two slopes.
+type fovRow struct {
+	depth      int
+	start, end slope
+}
+
+// slope represents the rational slope n/d, with d > 0.
+type slope struct {
+	n, d int
+}
+
+// fovQuadrant represents a quadrant's transform: the position at depth depth
+// and column col is origin + col*a + depth*b.
+type fovQuadrant struct {
+	a, b Point
+}
+
+var fovQuadrants = [...]fovQuadrant{
+	{Point{1, 0}, Point{0, -1}}, // north
+	{Point{0, 1}, Point{1, 0}},  // east
+	{Point{1, 0}, Point{0, 1}},  // south
+	{Point{0, 1}, Point{-1, 0}}, // west
+}
+
+// Compute marks as true in dst the positions visible from origin within the
+// given radius, and as false the other ones. Positions are relative to dst.
+// The blocksLight function reports whether a position blocks light. It may
+// be called with positions out of dst, for which it should usually return
+// true.
+func (fov *FOV) Compute(dst Grid[bool], origin Point, radius int, blocksLight func(Point) bool) {
+	dst.Fill(false)
+	fov.Visit(origin, radius, blocksLight, func(p Point) {
+		dst.Set(p, true)
+	})
+}
+
+// Visit calls visit once for each position visible from origin within the
+// given radius, starting with origin itself. The blocksLight function
+// reports whether a position blocks light. Nothing is visited for negative
+// radius.
+func (fov *FOV) Visit(origin Point, radius int, blocksLight func(Point) bool, visit func(Point)) {
+	if radius < 0 {
+		return
+	}
+	visit(origin)
+	if radius == 0 {
+		return
+	}
+	fov.prepare(radius)
+	for _, q := range fovQuadrants {
+		fov.rows = append(fov.rows[:0], fovRow{depth: 1, start: slope{-1, 1}, end: slope{1, 1}})
+		for len(fov.rows) > 0 {
+			row := fov.rows[len(fov.rows)-1]
+			fov.rows = fov.rows[:len(fov.rows)-1]
+			fov.scan(row, origin, q, blocksLight, visit)
+		}
+	}
+}
+
+// prepare updates the internal buffers for a new computation with the given
+// radius.
+func (fov *FOV) prepare(radius int) {
+	if fov.Shape == RadiusCircle && len(fov.half) != radius+1 {
+		fov.half = circleHalfWidths(radius)
+	}
+	n := 4 * (radius + 1)
+	if len(fov.diag) != n {
+		fov.diag = make([]uint32, n)
+		fov.gen = 0
+	}
+	fov.gen++
+	if fov.gen == 0 {
+		clear(fov.diag)
+		fov.gen = 1
+	}
+	fov.r = radius
+}
+
+// scan scans a row of quadrant q, visiting the visible positions and pushing
+// the next rows to scan on the stack.
+func (fov *FOV) scan(row fovRow, origin Point, q fovQuadrant, blocksLight func(Point) bool, visit func(Point)) {
+	// columns of the positions partly between the row's slopes
+	minCol := -ceilDiv(-(2*row.depth*row.start.n + row.start.d), 2*row.start.d)
+	maxCol := ceilDiv(2*row.depth*row.end.n-row.end.d, 2*row.end.d)
+	prevWall, first := false, true
+	for col := minCol; col <= maxCol; col++ {
+		d := q.a.Mul(col).Add(q.b.Mul(row.depth))
+		p := origin.Add(d)
+		wall := blocksLight(p)
+		if wall && !fov.ExcludeWalls || !wall && row.symmetric(col) {
+			fov.reveal(p, d, visit)
+		}
+		if !first && prevWall && !wall {
+			row.start = slope{2*col - 1, 2 * row.depth}
+		}
+		if !first && !prevWall && wall && row.depth < fov.r {
+			fov.rows = append(fov.rows, fovRow{depth: row.depth + 1, start: row.start, end: slope{2*col - 1, 2 * row.depth}})
+		}
+		prevWall, first = wall, false
+	}
+	if !first && !prevWall && row.depth < fov.r {
+		fov.rows = append(fov.rows, fovRow{depth: row.depth + 1, start: row.start, end: row.end})
+	}
+}
+
+// symmetric reports whether the center of the position at column col is
+// within the row's slopes.
+func (row fovRow) symmetric(col int) bool {
+	return col*row.start.d >= row.depth*row.start.n && col*row.end.d <= row.depth*row.end.n
+}
+
+// reveal visits position p at offset d from the origin if it is within
+// radius. Diagonal positions are shared by two quadrants, so they are marked
+// to be visited only once.
+func (fov *FOV) reveal(p, d Point, visit func(Point)) {
+	ad := d.Abs()
+	switch fov.Shape {
+	case RadiusDiamond:
+		if ad.X+ad.Y > fov.r {
+			return
+		}
+	case RadiusCircle:
+		if ad.X > fov.half[ad.Y] {
+			return
+		}
+	}
+	if ad.X == ad.Y {
+		i := ad.X
+		if d.X > 0 {
+			i += fov.r + 1
+		}
+		if d.Y > 0 {
+			i += 2 * (fov.r + 1)
+		}
+		if fov.diag[i] == fov.gen {
+			return
+		}
+		fov.diag[i] = fov.gen
+	}
+	visit(p)
+}
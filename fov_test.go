@@ -0,0 +1,115 @@
+package grid
+
+import "testing"
+
+func TestFOVOpen(t *testing.T) {
+	gd := NewGrid[bool](11, 11)
+	var fov FOV
+	origin := Point{5, 5}
+	open := func(p Point) bool { return !gd.Contains(p) }
+	disk := 0
+	for range Disk(origin, 4) {
+		disk++
+	}
+	for _, tc := range []struct {
+		shape RadiusShape
+		count int
+	}{{RadiusSquare, 81}, {RadiusDiamond, 41}, {RadiusCircle, disk}} {
+		fov.Shape = tc.shape
+		fov.Compute(gd, origin, 4, open)
+		count := 0
+		for v := range gd.Values() {
+			if v {
+				count++
+			}
+		}
+		if count != tc.count {
+			t.Errorf("bad visible count for shape %d: %d vs %d", tc.shape, count, tc.count)
+		}
+		visits := map[Point]int{}
+		fov.Visit(origin, 4, open, func(p Point) { visits[p]++ })
+		for p, n := range visits {
+			if n != 1 || !gd.At(p) {
+				t.Errorf("bad visit for shape %d: %v (%d)", tc.shape, p, n)
+			}
+		}
+		if len(visits) != tc.count {
+			t.Errorf("bad visit count for shape %d: %d", tc.shape, len(visits))
+		}
+	}
+	fov.Visit(origin, -1, open, func(p Point) { t.Errorf("visited %v for negative radius", p) })
+	for _, shape := range []RadiusShape{RadiusSquare, RadiusDiamond, RadiusCircle} {
+		fov.Shape = shape
+		var visited []Point
+		fov.Visit(origin, 0, open, func(p Point) { visited = append(visited, p) })
+		if len(visited) != 1 || visited[0] != origin {
+			t.Errorf("bad visit for shape %d and radius 0: %v", shape, visited)
+		}
+		fov.Visit(origin, 2, open, func(Point) {})
+	}
+}
+
+func TestFOVWalls(t *testing.T) {
+	gd := newMaze(
+		"#######",
+		"#.....#",
+		"#..#..#",
+		"#.....#",
+		"#######",
+	)
+	blocks := func(p Point) bool { return gd.At(p) != '.' }
+	var fov FOV
+	vis := NewGrid[bool](7, 5)
+	fov.Compute(vis, Point{1, 2}, 10, blocks)
+	for _, tc := range []struct {
+		p       Point
+		visible bool
+	}{
+		{Point{1, 2}, true},
+		{Point{2, 2}, true},
+		{Point{3, 2}, true},
+		{Point{4, 2}, false},
+		{Point{5, 2}, false},
+		{Point{5, 1}, true},
+		{Point{0, 0}, true},
+		{Point{6, 2}, false},
+	} {
+		if vis.At(tc.p) != tc.visible {
+			t.Errorf("bad visibility for %v: %v", tc.p, vis.At(tc.p))
+		}
+	}
+	fov.ExcludeWalls = true
+	fov.Compute(vis, Point{1, 2}, 10, blocks)
+	for p, v := range vis.All() {
+		if v && blocks(p) {
+			t.Errorf("wall %v visible", p)
+		}
+	}
+	if !vis.At(Point{2, 2}) || !vis.At(Point{5, 1}) {
+		t.Errorf("transparent positions should stay visible")
+	}
+}
+
+func TestFOVSymmetry(t *testing.T) {
+	gd := NewGrid[bool](20, 15)
+	var fov FOV
+	blocks := func(p Point) bool { return !gd.Contains(p) || gd.At(p) }
+	vis := map[Point]Grid[bool]{}
+	for n := 0; n < 10; n++ {
+		gd.Map(func(p Point, _ bool) bool { return randInt(100) < 30 })
+		clear(vis)
+		for p, wall := range gd.All() {
+			if !wall {
+				vis[p] = NewGrid[bool](20, 15)
+				fov.Compute(vis[p], p, 30, blocks)
+			}
+		}
+		for p, vp := range vis {
+			for q, vq := range vis {
+				if vp.At(q) != vq.At(p) {
+					t.Fatalf("asymmetric visibility between %v and %v", p, q)
+				}
+			}
+		}
+	}
+}
@@ -9,6 +9,7 @@ package grid
 
 import (
 	"fmt"
+	"math"
 )
 
 // Point represents an (X,Y) position in a grid.
@@ -56,6 +57,78 @@ func (p Point) Div(k int) Point {
 	return Point{X: p.X / k, Y: p.Y / k}
 }
 
+// Neg returns the vector -p.
+func (p Point) Neg() Point {
+	return Point{X: -p.X, Y: -p.Y}
+}
+
+// Abs returns the vector with the absolute values of the coordinates of p.
+func (p Point) Abs() Point {
+	return Point{X: abs(p.X), Y: abs(p.Y)}
+}
+
+// Sign returns the vector with the signs (-1, 0 or 1) of the coordinates of p.
+func (p Point) Sign() Point {
+	return Point{X: sign(p.X), Y: sign(p.Y)}
+}
+
+// Min returns the component-wise minimum of p and q.
+func (p Point) Min(q Point) Point {
+	return Point{X: min(p.X, q.X), Y: min(p.Y, q.Y)}
+}
+
+// Max returns the component-wise maximum of p and q.
+func (p Point) Max(q Point) Point {
+	return Point{X: max(p.X, q.X), Y: max(p.Y, q.Y)}
+}
+
+// Manhattan returns the Manhattan (taxicab) distance between p and q, that
+// is, the minimal number of 4-connected moves from p to q.
+func (p Point) Manhattan(q Point) int {
+	return abs(p.X-q.X) + abs(p.Y-q.Y)
+}
+
+// Chebyshev returns the Chebyshev distance between p and q, that is, the
+// minimal number of 8-connected moves from p to q.
+func (p Point) Chebyshev(q Point) int {
+	return max(abs(p.X-q.X), abs(p.Y-q.Y))
+}
+
+// DistSq returns the squared Euclidean distance between p and q. It is
+// suitable for comparing distances without floating point computations.
+func (p Point) DistSq(q Point) int {
+	d := p.Sub(q)
+	return d.X*d.X + d.Y*d.Y
+}
+
+// Euclidean returns the Euclidean distance between p and q.
+func (p Point) Euclidean(q Point) float64 {
+	return math.Sqrt(float64(p.DistSq(q)))
+}
+
+// Clamp returns the position within range rg that is closest to p. It is a
+// shorthand for rg.Nearest(p).
+func (p Point) Clamp(rg Range) Point {
+	return rg.Nearest(p)
+}
+
+// Mod returns the point q in range rg such that p.X-q.X is a multiple of
+// rg's width and p.Y-q.Y is a multiple of rg's height. It wraps positions
+// around rg, as on a torus. The range should not be empty.
+func (p Point) Mod(rg Range) Point {
+	max := rg.Size()
+	p = p.Sub(rg.Min)
+	p.X %= max.X
+	if p.X < 0 {
+		p.X += max.X
+	}
+	p.Y %= max.Y
+	if p.Y < 0 {
+		p.Y += max.Y
+	}
+	return p.Add(rg.Min)
+}
+
 // Range represents a rectangle in a grid that contains all the positions P
 // such that Min <= P < Max coordinate-wise. A range is well-formed if Min <=
 // Max. When non-empty, Min represents the upper-left position in the range,
@@ -204,6 +277,39 @@ func (rg Range) Union(r Range) Range {
 	return rg
 }
 
+// Difference returns the set of positions in rg that are not in r, as a list
+// of at most four disjoint ranges. The ranges above and below r span the
+// whole width of rg, and the ones on the left and right sides span the height
+// of the intersection. It returns nil if rg is contained in r.
+func (rg Range) Difference(r Range) []Range {
+	return rg.appendDifference(nil, r)
+}
+
+// appendDifference appends the ranges of rg.Difference(r) to rgs and returns
+// the updated slice.
+func (rg Range) appendDifference(rgs []Range, r Range) []Range {
+	if rg.Empty() {
+		return rgs
+	}
+	isect := rg.Intersect(r)
+	if isect.Empty() {
+		return append(rgs, rg)
+	}
+	if isect.Min.Y > rg.Min.Y {
+		rgs = append(rgs, Range{Min: rg.Min, Max: Point{rg.Max.X, isect.Min.Y}})
+	}
+	if isect.Min.X > rg.Min.X {
+		rgs = append(rgs, Range{Min: Point{rg.Min.X, isect.Min.Y}, Max: Point{isect.Min.X, isect.Max.Y}})
+	}
+	if isect.Max.X < rg.Max.X {
+		rgs = append(rgs, Range{Min: Point{isect.Max.X, isect.Min.Y}, Max: Point{rg.Max.X, isect.Max.Y}})
+	}
+	if isect.Max.Y < rg.Max.Y {
+		rgs = append(rgs, Range{Min: Point{rg.Min.X, isect.Max.Y}, Max: rg.Max})
+	}
+	return rgs
+}
+
 // Overlaps reports whether the two ranges have a non-zero intersection.
 func (rg Range) Overlaps(r Range) bool {
 	return !rg.Intersect(r).Empty()
@@ -214,6 +320,16 @@ func (rg Range) In(r Range) bool {
 	return rg.Intersect(r) == rg
 }
 
+// Nearest returns the position of the range that is closest to p, both for
+// the Manhattan and Euclidean distances. It returns p if p is in the range.
+// The range should be non-empty, otherwise rg.Min is returned.
+func (rg Range) Nearest(p Point) Point {
+	if rg.Empty() {
+		return rg.Min
+	}
+	return p.Max(rg.Min).Min(rg.Max.Shift(-1, -1))
+}
+
 // Iter calls a given function for all the positions of the range.
 func (rg Range) Iter(fn func(Point)) {
 	for y := rg.Min.Y; y < rg.Max.Y; y++ {
@@ -238,6 +354,7 @@ func (rg Range) Iter(fn func(Point)) {
 //	}
 //
 // Most iterations can be performed using the Slice, Fill, Copy, Map and Iter
+// methods, or with range-over-func loops using the All, Values and Rows
 // methods. An alternative choice is to use the Iterator method.
 //
 // Grid elements must be created with NewGrid.
@@ -588,7 +705,7 @@ func (gd Grid[T]) cp(src Grid[T]) Point {
 	wsrc := src.ug.Width
 	max := gd.Range().Intersect(src.Range()).Size()
 	idxmin := gd.rg.Min.Y*w + gd.rg.Min.X
-	idxsrcmin := src.rg.Min.Y*w + src.rg.Min.X
+	idxsrcmin := src.rg.Min.Y*wsrc + src.rg.Min.X
 	idxmax := (gd.rg.Min.Y + max.Y) * w
 	for idx, idxsrc := idxmin, idxsrcmin; idx < idxmax; idx, idxsrc = idx+w, idxsrc+wsrc {
 		copy(gd.ug.Cells[idx:idx+max.X], src.ug.Cells[idxsrc:idxsrc+max.X])
@@ -604,7 +721,7 @@ func (gd Grid[T]) cpv(src Grid[T]) Point {
 	cells := gd.ug.Cells
 	srccells := src.ug.Cells
 	for yi, yisrc := gd.rg.Min.Y*w, src.rg.Min.Y*wsrc; yi < yimax; yi, yisrc = yi+w, yisrc+wsrc {
-		ximax := yi + max.X
+		ximax := yi + gd.rg.Min.X + max.X
 		for xi, xisrc := yi+gd.rg.Min.X, yisrc+src.rg.Min.X; xi < ximax; xi, xisrc = xi+1, xisrc+1 {
 			cells[xi] = srccells[xisrc]
 		}
@@ -617,7 +734,7 @@ func (gd Grid[T]) cprev(src Grid[T]) Point {
 	wsrc := src.ug.Width
 	max := gd.Range().Intersect(src.Range()).Size()
 	idxmax := (gd.rg.Min.Y+max.Y-1)*w + gd.rg.Min.X
-	idxsrcmax := (src.rg.Min.Y+max.Y-1)*w + src.rg.Min.X
+	idxsrcmax := (src.rg.Min.Y+max.Y-1)*wsrc + src.rg.Min.X
 	idxmin := gd.rg.Min.Y * w
 	for idx, idxsrc := idxmax, idxsrcmax; idx >= idxmin; idx, idxsrc = idx-w, idxsrc-wsrc {
 		copy(gd.ug.Cells[idx:idx+max.X], src.ug.Cells[idxsrc:idxsrc+max.X])
@@ -625,8 +742,52 @@ func (gd Grid[T]) cprev(src Grid[T]) Point {
 	return max
 }
 
+// Scroll shifts the grid's content in place, so that the cell at position p
+// moves to position p+(dx,dy), and fills the exposed cells with the given
+// value. Cells moved out of the grid are lost. Content is moved with one
+// memmove per line, or a single one when scrolling vertically a grid slice
+// that spans whole lines of the underlying grid.
+func (gd Grid[T]) Scroll(dx, dy int, fill T) {
+	if gd.ug == nil {
+		return
+	}
+	max := gd.Size()
+	if abs(dx) >= max.X || abs(dy) >= max.Y {
+		gd.Fill(fill)
+		return
+	}
+	if dx == 0 && dy == 0 {
+		return
+	}
+	w := gd.ug.Width
+	if dx == 0 && max.X == w {
+		i := gd.rg.Min.Y * w
+		n := (max.Y - abs(dy)) * w
+		if dy > 0 {
+			copy(gd.ug.Cells[i+dy*w:i+dy*w+n], gd.ug.Cells[i:i+n])
+		} else {
+			copy(gd.ug.Cells[i:i+n], gd.ug.Cells[i-dy*w:i-dy*w+n])
+		}
+	} else {
+		rg := gd.Range()
+		src := gd.Slice(rg.Shift(-dx, -dy, -dx, -dy).Intersect(rg))
+		gd.Slice(rg.Shift(dx, dy, dx, dy).Intersect(rg)).Copy(src)
+	}
+	// fill exposed lines and columns
+	if dy > 0 {
+		gd.Slice(gd.Range().Lines(0, dy)).Fill(fill)
+	} else if dy < 0 {
+		gd.Slice(gd.Range().Lines(max.Y+dy, max.Y)).Fill(fill)
+	}
+	if dx > 0 {
+		gd.Slice(gd.Range().Columns(0, dx)).Fill(fill)
+	} else if dx < 0 {
+		gd.Slice(gd.Range().Columns(max.X+dx, max.X)).Fill(fill)
+	}
+}
+
 // GridIterator represents a stateful iterator for a grid. They are created
-// with the Iterator method.
+// with the Iterator, IteratorOrder or SpiralIterator methods.
 type GridIterator[T any] struct {
 	cells  []T   // grid cells
 	p      Point // iterator's current position
@@ -635,6 +796,8 @@ type GridIterator[T any] struct {
 	w      int   // underlying grid's width
 	nlstep int   // newline step
 	rg     Range // grid range
+	order  Order // traversal order
+	c      Point // spiral center
 }
 
 // Iterator returns an iterator that can be used to iterate on the grid. It may
@@ -663,13 +826,20 @@ func (gd Grid[T]) Iterator() GridIterator[T] {
 
 // Reset resets the iterator's state so that it can be used again.
 func (it *GridIterator[T]) Reset() {
+	if it.order != RowMajor {
+		it.reset()
+		return
+	}
 	it.p = Point{-1, 0}
 	it.i = it.rg.Min.Y*it.w + it.rg.Min.X - 1
 }
 
-// Next advances the iterator the next position in the grid, using row-major
-// ordering.
+// Next advances the iterator the next position in the grid, using the
+// iterator's traversal order, which is row-major ordering by default.
 func (it *GridIterator[T]) Next() bool {
+	if it.order != RowMajor {
+		return it.next()
+	}
 	if it.p.X < it.max.X {
 		it.p.X++
 		it.i++
@@ -709,3 +879,21 @@ func (it *GridIterator[T]) V() T {
 func (it *GridIterator[T]) SetV(c T) {
 	it.cells[it.i] = c
 }
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -23,6 +23,53 @@ func TestPoint(t *testing.T) {
 	}
 }
 
+func TestPointMetrics(t *testing.T) {
+	p := Point{2, -3}
+	q := Point{-1, 1}
+	if p.Manhattan(q) != 7 || q.Manhattan(p) != 7 {
+		t.Errorf("bad Manhattan: %d", p.Manhattan(q))
+	}
+	if p.Chebyshev(q) != 4 {
+		t.Errorf("bad Chebyshev: %d", p.Chebyshev(q))
+	}
+	if p.DistSq(q) != 25 {
+		t.Errorf("bad DistSq: %d", p.DistSq(q))
+	}
+	if p.Euclidean(q) != 5 {
+		t.Errorf("bad Euclidean: %v", p.Euclidean(q))
+	}
+	if p.Abs() != (Point{2, 3}) || p.Neg() != (Point{-2, 3}) {
+		t.Errorf("bad Abs or Neg: %v %v", p.Abs(), p.Neg())
+	}
+	if p.Sign() != (Point{1, -1}) || (Point{0, 5}).Sign() != (Point{0, 1}) {
+		t.Errorf("bad Sign: %v", p.Sign())
+	}
+	if p.Min(q) != (Point{-1, -3}) || p.Max(q) != (Point{2, 1}) {
+		t.Errorf("bad Min or Max: %v %v", p.Min(q), p.Max(q))
+	}
+}
+
+func TestPointClamp(t *testing.T) {
+	rg := NewRange(2, 3, 10, 8)
+	if (Point{5, 5}).Clamp(rg) != (Point{5, 5}) {
+		t.Errorf("bad clamp inside range")
+	}
+	if (Point{-5, 20}).Clamp(rg) != (Point{2, 7}) {
+		t.Errorf("bad clamp: %v", (Point{-5, 20}).Clamp(rg))
+	}
+	if rg.Nearest(Point{12, 4}) != (Point{9, 4}) {
+		t.Errorf("bad nearest: %v", rg.Nearest(Point{12, 4}))
+	}
+	if (Range{}).Nearest(Point{3, 3}) != (Point{}) {
+		t.Errorf("bad nearest for empty range")
+	}
+	for _, tc := range [][2]Point{{{5, 5}, {5, 5}}, {{10, 8}, {2, 3}}, {{-1, 2}, {7, 7}}, {{-14, 19}, {2, 4}}} {
+		if q := tc[0].Mod(rg); q != tc[1] {
+			t.Errorf("bad mod for %v: %v", tc[0], q)
+		}
+	}
+}
+
 func TestPointString(t *testing.T) {
 	p := Point{2, 3}
 	if p.String() != "(2,3)" {
@@ -102,6 +149,41 @@ func TestRangeUnion2(t *testing.T) {
 	}
 }
 
+func TestRangeDifference(t *testing.T) {
+	rg := NewRange(0, 0, 10, 10)
+	diff := rg.Difference(NewRange(3, 4, 6, 8))
+	if len(diff) != 4 {
+		t.Fatalf("bad difference: %v", diff)
+	}
+	area := 0
+	for i, r := range diff {
+		if !r.In(rg) || r.Overlaps(NewRange(3, 4, 6, 8)) {
+			t.Errorf("bad difference range: %v", r)
+		}
+		for _, r2 := range diff[i+1:] {
+			if r.Overlaps(r2) {
+				t.Errorf("overlapping ranges: %v %v", r, r2)
+			}
+		}
+		sz := r.Size()
+		area += sz.X * sz.Y
+	}
+	if area != 100-12 {
+		t.Errorf("bad area: %d", area)
+	}
+	diff = rg.Difference(NewRange(-5, -5, 5, 20))
+	if len(diff) != 1 || diff[0] != NewRange(5, 0, 10, 10) {
+		t.Errorf("bad side difference: %v", diff)
+	}
+	diff = rg.Difference(NewRange(20, 20, 30, 30))
+	if len(diff) != 1 || diff[0] != rg {
+		t.Errorf("bad disjoint difference: %v", diff)
+	}
+	if rg.Difference(rg.Grow(1)) != nil {
+		t.Errorf("non empty difference")
+	}
+}
+
 func TestRangeShift(t *testing.T) {
 	rg := NewRange(1, 2, 3, 4)
 	nrg := NewRange(2, 3, 4, 5)
@@ -737,6 +819,55 @@ func TestCopyShiftX(t *testing.T) {
 	})
 }
 
+func TestCopyWidths(t *testing.T) {
+	gd := NewGrid[int](20, 10)
+	gd.Map(func(p Point, _ int) int { return 100*p.Y + p.X })
+	small := NewGrid[int](6, 4)
+	if max := small.Copy(gd.Slice(NewRange(5, 3, 15, 10))); max != (Point{6, 4}) {
+		t.Errorf("bad copy size: %v", max)
+	}
+	small.Iter(func(p Point, c int) {
+		if c != 100*(p.Y+3)+p.X+5 {
+			t.Errorf("bad copy from wider grid at %v: %d", p, c)
+		}
+	})
+	narrow := NewGrid[int](3, 4)
+	narrow.Fill(-1)
+	gd.Slice(NewRange(5, 3, 15, 10)).Copy(narrow)
+	gd.Iter(func(p Point, c int) {
+		if p.In(NewRange(5, 3, 8, 7)) != (c == -1) {
+			t.Errorf("bad copy from narrow grid at %v: %d", p, c)
+		}
+	})
+}
+
+func TestScroll(t *testing.T) {
+	big := NewGrid[int](12, 9)
+	for _, gd := range []Grid[int]{big, big.Slice(NewRange(1, 2, 8, 7)), big.Slice(NewRange(0, 1, 12, 6))} {
+		max := gd.Size()
+		for _, d := range []Point{{0, 1}, {0, -2}, {3, 0}, {-1, 0}, {2, -3}, {-4, 1}, {0, 20}, {0, 0}} {
+			big.Map(func(p Point, _ int) int { return 100*p.Y + p.X })
+			orig := NewGrid[int](max.X, max.Y)
+			orig.Copy(gd)
+			gd.Scroll(d.X, d.Y, -1)
+			for p, c := range gd.All() {
+				want := -1
+				if q := p.Sub(d); q.In(gd.Range()) {
+					want = orig.At(q)
+				}
+				if c != want {
+					t.Errorf("bad scroll of %v by %v at %v: %d vs %d", gd.Range(), d, p, c, want)
+				}
+			}
+			for p, c := range big.All() {
+				if !p.In(gd.Bounds()) && c != 100*p.Y+p.X {
+					t.Errorf("scroll by %v modified cell out of slice at %v", d, p)
+				}
+			}
+		}
+	}
+}
+
 func TestResize(t *testing.T) {
 	gd := NewGrid[int](20, 10)
 	gd.Fill(1)
@@ -1024,3 +1155,17 @@ func BenchmarkGridFillVertical16(b *testing.B) {
 		gd.Fill(1)
 	}
 }
+
+func BenchmarkGridScrollLine(b *testing.B) {
+	gd := NewGrid[rune](200, 60)
+	for i := 0; i < b.N; i++ {
+		gd.Scroll(0, -1, ' ')
+	}
+}
+
+func BenchmarkGridScrollColumn(b *testing.B) {
+	gd := NewGrid[rune](200, 60)
+	for i := 0; i < b.N; i++ {
+		gd.Scroll(-1, 0, ' ')
+	}
+}
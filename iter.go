@@ -0,0 +1,76 @@
+package grid
+
+import "iter"
+
+// Points returns an iterator over all the positions of the range, in
+// row-major order.
+func (rg Range) Points() iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for y := rg.Min.Y; y < rg.Max.Y; y++ {
+			for x := rg.Min.X; x < rg.Max.X; x++ {
+				if !yield(Point{X: x, Y: y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// All returns an iterator over all the grid positions and cells, in row-major
+// order. It is the range-over-func equivalent of Iter.
+func (gd Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		if gd.ug == nil {
+			return
+		}
+		w := gd.ug.Width
+		yimax := gd.rg.Max.Y * w
+		cells := gd.ug.Cells
+		for y, yi := 0, gd.rg.Min.Y*w; yi < yimax; y, yi = y+1, yi+w {
+			ximax := yi + gd.rg.Max.X
+			for x, xi := 0, yi+gd.rg.Min.X; xi < ximax; x, xi = x+1, xi+1 {
+				if !yield(Point{X: x, Y: y}, cells[xi]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Values returns an iterator over all the grid cells, in row-major order.
+func (gd Grid[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if gd.ug == nil {
+			return
+		}
+		w := gd.ug.Width
+		yimax := gd.rg.Max.Y * w
+		cells := gd.ug.Cells
+		for yi := gd.rg.Min.Y * w; yi < yimax; yi += w {
+			for _, c := range cells[yi+gd.rg.Min.X : yi+gd.rg.Max.X] {
+				if !yield(c) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Rows returns an iterator over the lines of the grid, from top to bottom.
+// Each line is yielded along with its relative y coordinate as a grid slice
+// of height one that shares memory with the parent.
+func (gd Grid[T]) Rows() iter.Seq2[int, Grid[T]] {
+	return func(yield func(int, Grid[T]) bool) {
+		if gd.ug == nil {
+			return
+		}
+		row := Grid[T]{ug: gd.ug, rg: gd.rg}
+		for y := 0; y < gd.rg.Max.Y-gd.rg.Min.Y; y++ {
+			row.rg.Min.Y = gd.rg.Min.Y + y
+			row.rg.Max.Y = row.rg.Min.Y + 1
+			if !yield(y, row) {
+				return
+			}
+		}
+	}
+}
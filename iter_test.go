@@ -0,0 +1,126 @@
+package grid
+
+import "testing"
+
+func TestRangePoints(t *testing.T) {
+	rg := NewRange(2, 3, 7, 9)
+	count := 0
+	var prev Point
+	for p := range rg.Points() {
+		if !p.In(rg) {
+			t.Errorf("bad position: %v", p)
+		}
+		if count > 0 && (p.Y < prev.Y || p.Y == prev.Y && p.X <= prev.X) {
+			t.Errorf("not row-major: %v after %v", p, prev)
+		}
+		prev = p
+		count++
+	}
+	if count != 30 {
+		t.Errorf("bad count: %d", count)
+	}
+	for p := range rg.Points() {
+		if p != rg.Min {
+			t.Errorf("bad first point: %v", p)
+		}
+		break
+	}
+}
+
+func TestGridAll(t *testing.T) {
+	gd := NewGrid[int](10, 10)
+	gd.FillFunc(func(p Point) int {
+		return 100*p.X + p.Y
+	})
+	slice := gd.Slice(NewRange(2, 3, 6, 8))
+	count := 0
+	for p, c := range slice.All() {
+		if c != slice.At(p) {
+			t.Errorf("bad value %d at %v", c, p)
+		}
+		count++
+	}
+	if count != 20 {
+		t.Errorf("bad count: %d", count)
+	}
+	for p, c := range slice.All() {
+		if p.X == 1 && p.Y == 1 {
+			if c != 304 {
+				t.Errorf("bad value %d at %v", c, p)
+			}
+			break
+		}
+	}
+}
+
+func TestGridValues(t *testing.T) {
+	gd := NewGrid[int](10, 10)
+	gd.FillFunc(func(p Point) int {
+		return 10*p.Y + p.X
+	})
+	slice := gd.Slice(NewRange(5, 5, 7, 7))
+	var got []int
+	for c := range slice.Values() {
+		got = append(got, c)
+	}
+	want := []int{55, 56, 65, 66}
+	if len(got) != len(want) {
+		t.Fatalf("bad values: %v", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("bad values: %v", got)
+		}
+	}
+}
+
+func TestGridRows(t *testing.T) {
+	gd := NewGrid[int](10, 10)
+	slice := gd.Slice(NewRange(1, 2, 4, 6))
+	n := 0
+	for y, row := range slice.Rows() {
+		if row.Size() != (Point{3, 1}) {
+			t.Errorf("bad row size: %v", row.Size())
+		}
+		row.Fill(y + 1)
+		n++
+	}
+	if n != 4 {
+		t.Errorf("bad number of rows: %d", n)
+	}
+	gd.Iter(func(p Point, c int) {
+		if p.In(slice.Bounds()) {
+			if c != p.Y-1 {
+				t.Errorf("bad value %d at %v", c, p)
+			}
+		} else if c != 0 {
+			t.Errorf("not zero at %v: %d", p, c)
+		}
+	})
+}
+
+func TestGridIterNil(t *testing.T) {
+	var gd Grid[int]
+	for range gd.All() {
+		t.Errorf("non empty All")
+	}
+	for range gd.Values() {
+		t.Errorf("non empty Values")
+	}
+	for range gd.Rows() {
+		t.Errorf("non empty Rows")
+	}
+}
+
+func BenchmarkGridAll(b *testing.B) {
+	gd := NewGrid[int](80, 24)
+	gd.Fill(1)
+	for i := 0; i < b.N; i++ {
+		n := 0
+		for _, c := range gd.All() {
+			if c == 1 {
+				n++
+			}
+		}
+	}
+}
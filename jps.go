@@ -0,0 +1,279 @@
+package grid
+
+// JPS computes shortest paths on uniform-cost grids using Jump Point Search,
+// an optimization of A* that skips over positions of straight and diagonal
+// runs that cannot be part of a better path, expanding far fewer nodes on
+// large open maps.
+//
+// Movement is 8-connected, with cardinal moves costing 1, diagonal moves
+// costing sqrt(2), and no corner cutting, as with a PathFinder using
+// OctileHeuristic and DiagonalNoCornerCut.
+//
+// Like PathFinder, a JPS keeps its internal buffers across calls, and should
+// not be used concurrently.
+type JPS struct {
+	passable Grid[bool]
+	jumps    []jumpDists // JPS+ precomputed jump distances, if any
+	search
+}
+
+// jumpDists stores, for each of the 8 directions, the distance to the next
+// jump point if positive, or minus the number of free positions before a
+// blocked one otherwise.
+type jumpDists [8]int32
+
+// NewJPS returns a jump point search path finder on the given passability
+// grid, where true means passable. The grid's content may change between
+// searches, but Precompute has to be called again after changes if JPS+ is
+// used.
+func NewJPS(passable Grid[bool]) *JPS {
+	j := &JPS{passable: passable}
+	j.init(passable.Range())
+	return j
+}
+
+// Precompute enables JPS+ by precomputing, for each position and direction,
+// the distance to the next jump point or obstacle. Subsequent searches then
+// jump in constant time, at the cost of memory proportional to 32 bytes per
+// position. It should be called again whenever the passability grid changes.
+func (j *JPS) Precompute() {
+	max := j.passable.Size()
+	n := max.X * max.Y
+	if cap(j.jumps) >= n {
+		j.jumps = j.jumps[:n]
+	} else {
+		j.jumps = make([]jumpDists, n)
+	}
+	// Straight directions first, as diagonal distances depend on them.
+	for _, d := range [...]Direction{N, E, S, W} {
+		j.precomputeStraight(d)
+	}
+	for _, d := range [...]Direction{NE, SE, SW, NW} {
+		j.precomputeDiagonal(d)
+	}
+}
+
+// DisablePrecompute disables JPS+ and frees the precomputed data.
+func (j *JPS) DisablePrecompute() {
+	j.jumps = nil
+}
+
+func (j *JPS) free(p Point) bool {
+	return j.passable.At(p)
+}
+
+// forced reports whether position p, reached by a straight move in direction
+// d, has a forced neighbor, which makes it a jump point.
+func (j *JPS) forced(p, d Point) bool {
+	if d.X != 0 {
+		return j.free(p.Shift(0, -1)) && !j.free(p.Shift(-d.X, -1)) ||
+			j.free(p.Shift(0, 1)) && !j.free(p.Shift(-d.X, 1))
+	}
+	return j.free(p.Shift(-1, 0)) && !j.free(p.Shift(-1, -d.Y)) ||
+		j.free(p.Shift(1, 0)) && !j.free(p.Shift(1, -d.Y))
+}
+
+// diagonalStep reports whether the diagonal move from p by d is possible.
+func (j *JPS) diagonalStep(p, d Point) bool {
+	return j.free(p.Shift(d.X, 0)) && j.free(p.Shift(0, d.Y)) && j.free(p.Add(d))
+}
+
+func (j *JPS) precomputeStraight(dir Direction) {
+	d := dir.Delta()
+	// Walk each line backwards from the direction, so that the distance
+	// of a position can be deduced from the one of its successor.
+	it := j.passable.IteratorOrder(RowMajor)
+	if d.X > 0 || d.Y > 0 {
+		it = j.passable.IteratorOrder(ReverseRowMajor)
+	}
+	for it.Next() {
+		p := it.P()
+		q := p.Add(d)
+		i := j.idx(p)
+		switch {
+		case !j.free(q):
+			j.jumps[i][dir] = 0
+		case j.forced(q, d):
+			j.jumps[i][dir] = 1
+		default:
+			next := j.jumps[j.idx(q)][dir]
+			if next > 0 {
+				j.jumps[i][dir] = next + 1
+			} else {
+				j.jumps[i][dir] = next - 1
+			}
+		}
+	}
+}
+
+func (j *JPS) precomputeDiagonal(dir Direction) {
+	d := dir.Delta()
+	h, v := E, S // straight components of the diagonal direction
+	if d.X < 0 {
+		h = W
+	}
+	if d.Y < 0 {
+		v = N
+	}
+	it := j.passable.IteratorOrder(RowMajor)
+	if d.Y > 0 {
+		it = j.passable.IteratorOrder(ReverseRowMajor)
+	}
+	for it.Next() {
+		p := it.P()
+		q := p.Add(d)
+		i := j.idx(p)
+		if !j.diagonalStep(p, d) {
+			j.jumps[i][dir] = 0
+			continue
+		}
+		qi := j.idx(q)
+		if j.jumps[qi][h] > 0 || j.jumps[qi][v] > 0 {
+			j.jumps[i][dir] = 1
+			continue
+		}
+		next := j.jumps[qi][dir]
+		if next > 0 {
+			j.jumps[i][dir] = next + 1
+		} else {
+			j.jumps[i][dir] = next - 1
+		}
+	}
+}
+
+// Path appends to buf[:0] the jump points of a shortest path from position
+// from to position to, both included, and returns the updated slice.
+// Successive jump points are joined by straight or diagonal runs, so that
+// ExpandPath can be used to get the full path. It returns an empty slice if
+// there is no path, or if from or to are not passable.
+func (j *JPS) Path(buf []Point, from, to Point) []Point {
+	buf = buf[:0]
+	if !j.free(from) || !j.free(to) {
+		return buf
+	}
+	start, goal := j.idx(from), j.idx(to)
+	j.start(start, OctileHeuristic(from, to))
+	for {
+		n, ok := j.next()
+		if !ok {
+			return buf
+		}
+		if n.i == goal {
+			return j.appendPath(buf, start, goal)
+		}
+		p := j.point(n.i)
+		var pdir Point // direction from parent
+		if n.i != start {
+			pdir = p.Sub(j.point(j.parent[n.i])).Sign()
+		}
+		for dir := N; dir < NoDirection; dir++ {
+			d := dir.Delta()
+			if !j.successor(p, pdir, d) {
+				continue
+			}
+			var q Point
+			if j.jumps != nil {
+				q, ok = j.jumpPlus(p, dir, to)
+			} else {
+				q, ok = j.jump(p, d, to)
+			}
+			if ok {
+				j.relax(j.idx(q), n.i, n.g+OctileHeuristic(p, q), OctileHeuristic(q, to))
+			}
+		}
+	}
+}
+
+// successor reports whether direction d should be explored from p, reached
+// in direction pdir, following the neighbor pruning rules.
+func (j *JPS) successor(p, pdir, d Point) bool {
+	if d.X != 0 && d.Y != 0 && !j.diagonalStep(p, d) || !j.free(p.Add(d)) {
+		return false
+	}
+	switch {
+	case pdir == Point{}:
+		return true
+	case pdir.X != 0 && pdir.Y != 0:
+		// natural neighbors of a diagonal move
+		return d == pdir || d == Point{pdir.X, 0} || d == Point{0, pdir.Y}
+	case pdir.X != 0:
+		// forward moves, and perpendicular moves that may be forced
+		return d.X == pdir.X || d.X == 0
+	default:
+		return d.Y == pdir.Y || d.Y == 0
+	}
+}
+
+// jump returns the next jump point from p in direction d, if any.
+func (j *JPS) jump(p, d, goal Point) (Point, bool) {
+	if d.X == 0 || d.Y == 0 {
+		return j.jumpStraight(p.Add(d), d, goal)
+	}
+	for q := p.Add(d); ; q = q.Add(d) {
+		if q == goal {
+			return q, true
+		}
+		if _, ok := j.jumpStraight(q.Shift(d.X, 0), Point{d.X, 0}, goal); ok {
+			return q, true
+		}
+		if _, ok := j.jumpStraight(q.Shift(0, d.Y), Point{0, d.Y}, goal); ok {
+			return q, true
+		}
+		if !j.diagonalStep(q, d) {
+			return Point{}, false
+		}
+	}
+}
+
+// jumpStraight returns the first jump point in direction d starting at q,
+// included, if any.
+func (j *JPS) jumpStraight(q, d, goal Point) (Point, bool) {
+	for ; j.free(q); q = q.Add(d) {
+		if q == goal || j.forced(q, d) {
+			return q, true
+		}
+	}
+	return Point{}, false
+}
+
+// jumpPlus is like jump, but uses precomputed jump distances.
+func (j *JPS) jumpPlus(p Point, dir Direction, goal Point) (Point, bool) {
+	d := dir.Delta()
+	dist := int(j.jumps[j.idx(p)][dir])
+	reach := abs(dist) // number of positions reachable in direction d
+	g := goal.Sub(p)
+	if dir.Cardinal() {
+		if d.X != 0 && g.Y == 0 && sign(g.X) == d.X && abs(g.X) <= reach ||
+			d.Y != 0 && g.X == 0 && sign(g.Y) == d.Y && abs(g.Y) <= reach {
+			return goal, true
+		}
+	} else if sign(g.X) == d.X && sign(g.Y) == d.Y {
+		// goal in the quadrant: stop on its line or column
+		if k := min(abs(g.X), abs(g.Y)); k <= reach {
+			return p.Add(d.Mul(k)), true
+		}
+	}
+	if dist > 0 {
+		return p.Add(d.Mul(dist)), true
+	}
+	return Point{}, false
+}
+
+// ExpandPath appends to buf[:0] the full position-by-position path going
+// through the given waypoints, such as the ones returned by JPS.Path, and
+// returns the updated slice. Waypoints are joined using Line.
+func ExpandPath(buf []Point, waypoints []Point) []Point {
+	buf = buf[:0]
+	for i, p := range waypoints {
+		if i == 0 {
+			buf = append(buf, p)
+			continue
+		}
+		it := Line(waypoints[i-1], p)
+		it.Next() // skip already appended start
+		for it.Next() {
+			buf = append(buf, it.P())
+		}
+	}
+	return buf
+}
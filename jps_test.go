@@ -0,0 +1,98 @@
+package grid
+
+import (
+	"math"
+	"testing"
+)
+
+func pathCost(path []Point) float64 {
+	c := 0.0
+	for i := 1; i < len(path); i++ {
+		c += OctileHeuristic(path[i-1], path[i])
+	}
+	return c
+}
+
+func TestJPS(t *testing.T) {
+	gd := newMaze(
+		"..........",
+		".########.",
+		".#......#.",
+		".#.####.#.",
+		"...#..#...",
+		"####..####",
+	)
+	passable := NewGrid[bool](10, 6)
+	passable.Map(func(p Point, _ bool) bool { return gd.At(p) != '#' })
+	j := NewJPS(passable)
+	for _, plus := range []bool{false, true} {
+		if plus {
+			j.Precompute()
+		}
+		wps := j.Path(nil, Point{0, 0}, Point{2, 2})
+		if len(wps) < 2 || wps[0] != (Point{0, 0}) || wps[len(wps)-1] != (Point{2, 2}) {
+			t.Fatalf("bad waypoints (plus: %v): %v", plus, wps)
+		}
+		path := ExpandPath(nil, wps)
+		for i := 1; i < len(path); i++ {
+			if path[i].Chebyshev(path[i-1]) != 1 || !passable.At(path[i]) {
+				t.Errorf("bad path step (plus: %v) %v -> %v", plus, path[i-1], path[i])
+			}
+		}
+		if path := j.Path(wps, Point{0, 0}, Point{4, 4}); len(path) != 0 {
+			t.Errorf("found path to unreachable position: %v", path)
+		}
+		if path := j.Path(wps, Point{0, 0}, Point{20, 2}); len(path) != 0 {
+			t.Errorf("found path out of range: %v", path)
+		}
+		if path := j.Path(wps, Point{3, 2}, Point{3, 2}); len(path) != 1 {
+			t.Errorf("bad trivial path: %v", path)
+		}
+	}
+}
+
+func TestJPSShortest(t *testing.T) {
+	passable := NewGrid[bool](30, 20)
+	pf := NewPathFinder(passable.Range(), func(p, q Point) (float64, bool) {
+		if !passable.At(q) {
+			return 0, false
+		}
+		return OctileHeuristic(p, q), true
+	})
+	j := NewJPS(passable)
+	var path, wps []Point
+	for n := 0; n < 50; n++ {
+		passable.Map(func(p Point, _ bool) bool { return randInt(100) >= 25 })
+		j.DisablePrecompute()
+		if n%2 == 0 {
+			j.Precompute()
+		}
+		for k := 0; k < 10; k++ {
+			from := Point{randInt(30), randInt(20)}
+			to := Point{randInt(30), randInt(20)}
+			if !passable.At(from) {
+				// A* does not check the starting position
+				continue
+			}
+			path = pf.Path(path, from, to)
+			wps = j.Path(wps, from, to)
+			if len(path) == 0 || len(wps) == 0 {
+				if len(path) != len(wps) {
+					t.Fatalf("path existence mismatch %v -> %v: %v vs %v", from, to, path, wps)
+				}
+				continue
+			}
+			if c, cj := pathCost(path), pathCost(wps); math.Abs(c-cj) > 1e-9 {
+				t.Fatalf("cost mismatch %v -> %v: %v vs %v", from, to, c, cj)
+			}
+			full := ExpandPath(nil, wps)
+			for i := 1; i < len(full); i++ {
+				p, q := full[i-1], full[i]
+				if p.Chebyshev(q) != 1 || !passable.At(q) ||
+					!passable.At(Point{p.X, q.Y}) || !passable.At(Point{q.X, p.Y}) {
+					t.Fatalf("bad expanded step %v -> %v", p, q)
+				}
+			}
+		}
+	}
+}
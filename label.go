@@ -0,0 +1,120 @@
+package grid
+
+// Component represents statistics about a connected component, as computed by
+// Label or LabelEq.
+type Component struct {
+	Label  int   // component's label
+	Count  int   // number of positions in the component
+	Bounds Range // smallest range containing the component
+	P      Point // first position of the component in row-major order
+}
+
+// Label computes the connected components of the positions of the grid whose
+// cells satisfy match, using the given connectivity. It returns a grid of
+// same size as gd with the label of each position, and the statistics of each
+// component. Labels are numbered from 1 in row-major order of first
+// appearance, so that comps[i].Label == i+1, and positions whose cell does not
+// match get label 0. Positions are relative to gd.
+//
+// It uses a two-pass algorithm with a union-find structure, so its running
+// time is almost linear in the number of cells, and it allocates only the
+// returned values and a small equivalence table.
+func Label[T any](gd Grid[T], conn Connectivity, match func(T) bool) (Grid[int], []Component) {
+	return label(gd, conn, match, nil)
+}
+
+// LabelEq is like Label, but all positions are labeled, and two adjacent
+// positions belong to the same component if eq returns true for their cells.
+// The function eq should be an equivalence relation.
+func LabelEq[T any](gd Grid[T], conn Connectivity, eq func(T, T) bool) (Grid[int], []Component) {
+	return label(gd, conn, nil, eq)
+}
+
+// label implements Label and LabelEq: a nil match means all positions are
+// considered, and a nil eq means all adjacent considered positions are
+// connected.
+func label[T any](gd Grid[T], conn Connectivity, match func(T) bool, eq func(T, T) bool) (Grid[int], []Component) {
+	max := gd.Size()
+	labels := NewGrid[int](max.X, max.Y)
+	if gd.ug == nil || max.X == 0 || max.Y == 0 {
+		return labels, nil
+	}
+	// already visited neighbors in row-major order
+	prev := []Point{{-1, 0}, {0, -1}}
+	if conn == Conn8 {
+		prev = []Point{{-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+	}
+	w := gd.ug.Width
+	cells := gd.ug.Cells
+	lcells := labels.ug.Cells
+	uf := unionFind{0}
+	for y, yi := 0, gd.rg.Min.Y*w+gd.rg.Min.X; y < max.Y; y, yi = y+1, yi+w {
+		for x := 0; x < max.X; x++ {
+			c := cells[yi+x]
+			if match != nil && !match(c) {
+				continue
+			}
+			l := 0
+			for _, d := range prev {
+				qx, qy := x+d.X, y+d.Y
+				if qx < 0 || qx >= max.X || qy < 0 {
+					continue
+				}
+				ql := lcells[qy*max.X+qx]
+				if ql == 0 || eq != nil && !eq(cells[yi+d.Y*w+qx], c) {
+					continue
+				}
+				if l == 0 {
+					l = uf.find(ql)
+				} else {
+					l = uf.union(l, ql)
+				}
+			}
+			if l == 0 {
+				l = len(uf)
+				uf = append(uf, l)
+			}
+			lcells[y*max.X+x] = l
+		}
+	}
+	final := make([]int, len(uf))
+	comps := []Component{}
+	for i, l := range lcells {
+		if l == 0 {
+			continue
+		}
+		r := uf.find(l)
+		p := Point{i % max.X, i / max.X}
+		if final[r] == 0 {
+			final[r] = len(comps) + 1
+			comps = append(comps, Component{Label: final[r], Bounds: Range{p, p.Shift(1, 1)}, P: p})
+		}
+		cp := &comps[final[r]-1]
+		cp.Count++
+		cp.Bounds = cp.Bounds.Union(Range{p, p.Shift(1, 1)})
+		lcells[i] = final[r]
+	}
+	return labels, comps
+}
+
+// unionFind represents disjoint sets of provisional labels, with uf[l] being
+// the parent of label l. Roots are always the smallest label of their set.
+type unionFind []int
+
+func (uf unionFind) find(l int) int {
+	for uf[l] != l {
+		uf[l] = uf[uf[l]]
+		l = uf[l]
+	}
+	return l
+}
+
+func (uf unionFind) union(a, b int) int {
+	ra, rb := uf.find(a), uf.find(b)
+	if ra < rb {
+		uf[rb] = ra
+		return ra
+	}
+	uf[ra] = rb
+	return rb
+}
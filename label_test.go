@@ -0,0 +1,89 @@
+package grid
+
+import "testing"
+
+func TestLabel(t *testing.T) {
+	gd := newMaze(
+		"..#.....",
+		"..#.####",
+		"###.#...",
+		"....#.#.",
+		".#.#..#.",
+	)
+	labels, comps := Label(gd, Conn4, isFloor)
+	if len(comps) != 3 {
+		t.Fatalf("bad number of components: %v", comps)
+	}
+	if labels.Size() != gd.Size() {
+		t.Errorf("bad labels size: %v", labels.Size())
+	}
+	if comps[0].Count != 4 || comps[0].Bounds != NewRange(0, 0, 2, 2) || comps[0].P != (Point{0, 0}) {
+		t.Errorf("bad first component: %+v", comps[0])
+	}
+	if comps[1].P != (Point{3, 0}) || comps[1].Bounds != NewRange(0, 0, 8, 5) {
+		t.Errorf("bad second component: %+v", comps[1])
+	}
+	if comps[2].P != (Point{5, 2}) || comps[2].Count != 8 {
+		t.Errorf("bad third component: %+v", comps[2])
+	}
+	gd.Iter(func(p Point, r rune) {
+		if (r == '#') != (labels.At(p) == 0) {
+			t.Errorf("bad label %d at %v", labels.At(p), p)
+		}
+	})
+	_, comps = Label(gd, Conn8, isFloor)
+	if len(comps) != 2 {
+		t.Errorf("bad number of 8-connected components: %v", comps)
+	}
+	_, comps = LabelEq(gd, Conn4, func(a, b rune) bool { return a == b })
+	if len(comps) != 3+5 {
+		t.Errorf("bad number of equivalence components: %d", len(comps))
+	}
+	var nilgd Grid[rune]
+	if _, comps := Label(nilgd, Conn4, isFloor); len(comps) != 0 {
+		t.Errorf("non empty components for nil grid")
+	}
+}
+
+func TestLabelFlood(t *testing.T) {
+	gd := NewGrid[int](40, 30)
+	slice := gd.Slice(NewRange(3, 2, 37, 29))
+	for i := 0; i < 10; i++ {
+		gd.FillFunc(func(p Point) int { return randInt(3) })
+		for _, conn := range []Connectivity{Conn4, Conn8} {
+			labels, comps := LabelEq(slice, conn, func(a, b int) bool { return a == b })
+			total := 0
+			for _, cp := range comps {
+				v := slice.At(cp.P)
+				region := FloodRegion(slice, cp.P, conn, func(c int) bool { return c == v })
+				n := 0
+				region.Iter(func(p Point, in bool) {
+					if in != (labels.At(p) == cp.Label) {
+						t.Errorf("bad label at %v", p)
+					}
+					if in {
+						n++
+						if !p.In(cp.Bounds) {
+							t.Errorf("%v not in bounds %v", p, cp.Bounds)
+						}
+					}
+				})
+				if n != cp.Count {
+					t.Errorf("bad count %d for component %+v", n, cp)
+				}
+				total += cp.Count
+			}
+			if max := slice.Size(); total != max.X*max.Y {
+				t.Errorf("bad total count: %d", total)
+			}
+		}
+	}
+}
+
+func BenchmarkLabel(b *testing.B) {
+	gd := NewGrid[bool](1000, 1000)
+	gd.FillFunc(func(p Point) bool { return randInt(2) == 0 })
+	for i := 0; i < b.N; i++ {
+		Label(gd, Conn8, func(c bool) bool { return c })
+	}
+}
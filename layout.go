@@ -0,0 +1,70 @@
+package grid
+
+// Inset returns the range shrunk by n cells on each side, or an empty range if
+// there is no space left. A negative n grows the range.
+func (rg Range) Inset(n int) Range {
+	return rg.Shift(n, n, -n, -n)
+}
+
+// Grow returns the range grown by n cells on each side. It is a shorthand for
+// rg.Inset(-n).
+func (rg Range) Grow(n int) Range {
+	return rg.Inset(-n)
+}
+
+// Center returns a range of the given (width, height) size centered within
+// rg. If the size is bigger than the range in some dimension, the result is
+// reduced to fit within rg in that dimension. When the space left cannot be
+// evenly distributed, the extra cell goes to the right or bottom.
+func (rg Range) Center(size Point) Range {
+	d := rg.Size().Sub(size).Div(2)
+	nrg := Range{Min: rg.Min.Add(d)}
+	nrg.Max = nrg.Min.Add(size)
+	return rg.Intersect(nrg)
+}
+
+// SplitX splits the range into two ranges at relative column x: the first
+// one contains columns before x, and the second one the remaining columns. If
+// x is out of bounds, one of the returned ranges is empty.
+func (rg Range) SplitX(x int) (Range, Range) {
+	w := rg.Size().X
+	return rg.Columns(0, x), rg.Columns(x, w)
+}
+
+// SplitY splits the range into two ranges at relative line y: the first one
+// contains lines before y, and the second one the remaining lines. If y is out
+// of bounds, one of the returned ranges is empty.
+func (rg Range) SplitY(y int) (Range, Range) {
+	h := rg.Size().Y
+	return rg.Lines(0, y), rg.Lines(y, h)
+}
+
+// SplitXPercent is like SplitX, but the split column is given as a percentage
+// of the range's width, rounded down.
+func (rg Range) SplitXPercent(pct int) (Range, Range) {
+	return rg.SplitX(rg.Size().X * pct / 100)
+}
+
+// SplitYPercent is like SplitY, but the split line is given as a percentage
+// of the range's height, rounded down.
+func (rg Range) SplitYPercent(pct int) (Range, Range) {
+	return rg.SplitY(rg.Size().Y * pct / 100)
+}
+
+// SplitN divides the range into a matrix of cols x rows sub-ranges of
+// (almost) equal size, covering the whole range without overlap. The returned
+// grid has size (cols, rows), and its cell at position (i, j) is the
+// sub-range of column i and row j. When the range's size is not a multiple of
+// the number of divisions, the extra cells are distributed among the
+// sub-ranges. The number of columns and rows should be positive or null.
+func (rg Range) SplitN(cols, rows int) Grid[Range] {
+	gd := NewGrid[Range](cols, rows)
+	max := rg.Size()
+	gd.FillFunc(func(p Point) Range {
+		return Range{
+			Min: rg.Min.Shift(p.X*max.X/cols, p.Y*max.Y/rows),
+			Max: rg.Min.Shift((p.X+1)*max.X/cols, (p.Y+1)*max.Y/rows),
+		}
+	})
+	return gd
+}
@@ -0,0 +1,90 @@
+package grid
+
+import "testing"
+
+func TestRangeInsetGrow(t *testing.T) {
+	rg := NewRange(2, 3, 12, 9)
+	if rg.Inset(2) != NewRange(4, 5, 10, 7) {
+		t.Errorf("bad inset: %v", rg.Inset(2))
+	}
+	if !rg.Inset(3).Empty() {
+		t.Errorf("non empty inset: %v", rg.Inset(3))
+	}
+	if rg.Grow(1) != NewRange(1, 2, 13, 10) {
+		t.Errorf("bad grow: %v", rg.Grow(1))
+	}
+	if rg.Grow(2).Inset(2) != rg {
+		t.Errorf("bad grow and inset")
+	}
+}
+
+func TestRangeCenter(t *testing.T) {
+	rg := NewRange(0, 0, 80, 24)
+	c := rg.Center(Point{20, 10})
+	if c != NewRange(30, 7, 50, 17) {
+		t.Errorf("bad center: %v", c)
+	}
+	c = rg.Center(Point{21, 11})
+	if c.Size() != (Point{21, 11}) || !c.In(rg) {
+		t.Errorf("bad odd center: %v", c)
+	}
+	c = rg.Center(Point{100, 10})
+	if c != NewRange(0, 7, 80, 17) {
+		t.Errorf("bad oversized center: %v", c)
+	}
+}
+
+func TestRangeSplit(t *testing.T) {
+	rg := NewRange(2, 3, 12, 9)
+	l, r := rg.SplitX(4)
+	if l != NewRange(2, 3, 6, 9) || r != NewRange(6, 3, 12, 9) {
+		t.Errorf("bad SplitX: %v %v", l, r)
+	}
+	l, r = rg.SplitX(-2)
+	if !l.Empty() || r != rg {
+		t.Errorf("bad negative SplitX: %v %v", l, r)
+	}
+	l, r = rg.SplitX(20)
+	if l != rg || !r.Empty() {
+		t.Errorf("bad big SplitX: %v %v", l, r)
+	}
+	u, d := rg.SplitY(1)
+	if u != NewRange(2, 3, 12, 4) || d != NewRange(2, 4, 12, 9) {
+		t.Errorf("bad SplitY: %v %v", u, d)
+	}
+	l, r = rg.SplitXPercent(30)
+	if l.Size().X != 3 || r.Size().X != 7 {
+		t.Errorf("bad SplitXPercent: %v %v", l, r)
+	}
+	u, d = rg.SplitYPercent(50)
+	if u.Size().Y != 3 || d.Size().Y != 3 {
+		t.Errorf("bad SplitYPercent: %v %v", u, d)
+	}
+}
+
+func TestRangeSplitN(t *testing.T) {
+	rg := NewRange(1, 1, 11, 8)
+	cells := rg.SplitN(3, 2)
+	if cells.Size() != (Point{3, 2}) {
+		t.Fatalf("bad size: %v", cells.Size())
+	}
+	area := 0
+	cells.Iter(func(p Point, c Range) {
+		if !c.In(rg) {
+			t.Errorf("cell %v out of range: %v", p, c)
+		}
+		sz := c.Size()
+		if sz.X < 3 || sz.X > 4 || sz.Y < 3 || sz.Y > 4 {
+			t.Errorf("bad cell size at %v: %v", p, c)
+		}
+		cells.Iter(func(q Point, c2 Range) {
+			if p != q && c.Overlaps(c2) {
+				t.Errorf("overlapping cells: %v %v", c, c2)
+			}
+		})
+		area += sz.X * sz.Y
+	})
+	if area != 70 {
+		t.Errorf("bad total area: %d", area)
+	}
+}
@@ -0,0 +1,140 @@
+package grid
+
+import "iter"
+
+// LineIterator represents a stateful iterator over the positions of a
+// rasterized line segment, from a start position to an end position, both
+// included. Successive positions are 8-connected. They are created with the
+// Line or SymmetricLine functions, and used as follows:
+//
+//	it := grid.Line(p, q)
+//	for it.Next() {
+//		// do something with it.P()
+//	}
+type LineIterator struct {
+	from  Point // start position
+	p     Point // current position
+	major Point // unit step along major axis
+	minor Point // unit step along minor axis
+	n     int   // number of steps (major axis length)
+	am    int   // minor axis length
+	c     int   // initial error, determines rounding of ties
+	err   int   // current error
+	i     int   // current step
+}
+
+// Line returns an iterator over the positions of the line segment from p to
+// q, computed with Bresenham's algorithm. When the line passes exactly
+// between two cells, the one closer to the start is chosen, so that the line
+// from p to q and the line from q to p may differ. See SymmetricLine for a
+// variant that does not depend on the direction.
+func Line(p, q Point) LineIterator {
+	return newLine(p, q, false)
+}
+
+// SymmetricLine is like Line, but it visits the same positions for the line
+// from p to q and the line from q to p (in reverse order). Ties are always
+// broken toward the bottom or right cell.
+func SymmetricLine(p, q Point) LineIterator {
+	return newLine(p, q, true)
+}
+
+func newLine(p, q Point, sym bool) LineIterator {
+	d := q.Sub(p)
+	it := LineIterator{from: p}
+	if abs(d.X) >= abs(d.Y) {
+		it.n, it.am = abs(d.X), abs(d.Y)
+		it.major = Point{sign(d.X), 0}
+		it.minor = Point{0, sign(d.Y)}
+	} else {
+		it.n, it.am = abs(d.Y), abs(d.X)
+		it.major = Point{0, sign(d.Y)}
+		it.minor = Point{sign(d.X), 0}
+	}
+	// The minor axis offset at step i is floor((2*i*am + c) / 2n): c = n
+	// rounds ties toward positive coordinates, and c = n-1 toward the
+	// start.
+	it.c = it.n - 1
+	if sym && (it.minor.X > 0 || it.minor.Y > 0) {
+		it.c = it.n
+	}
+	it.Reset()
+	return it
+}
+
+// Reset resets the iterator's state so that it can be used again.
+func (it *LineIterator) Reset() {
+	it.i = -1
+	it.p = it.from
+	it.err = it.c
+}
+
+// Next advances the iterator to the next position of the line. It returns
+// false when the end position has already been visited.
+func (it *LineIterator) Next() bool {
+	if it.i >= it.n {
+		return false
+	}
+	it.i++
+	if it.i == 0 {
+		return true
+	}
+	it.p = it.p.Add(it.major)
+	it.err += 2 * it.am
+	if it.err >= 2*it.n {
+		it.err -= 2 * it.n
+		it.p = it.p.Add(it.minor)
+	}
+	return true
+}
+
+// P returns the iterator's current position.
+func (it *LineIterator) P() Point {
+	return it.p
+}
+
+// Len returns the total number of positions in the line.
+func (it *LineIterator) Len() int {
+	return it.n + 1
+}
+
+// Points returns an iterator over all the positions of the line, starting
+// from the beginning. The state of it is not modified.
+func (it *LineIterator) Points() iter.Seq[Point] {
+	lit := *it
+	return func(yield func(Point) bool) {
+		lit.Reset()
+		for lit.Next() {
+			if !yield(lit.p) {
+				return
+			}
+		}
+	}
+}
+
+// DrawLine sets the cells of the line from p to q, as given by Line, to
+// value c. Positions out of the grid are ignored.
+func (gd Grid[T]) DrawLine(p, q Point, c T) {
+	it := Line(p, q)
+	for it.Next() {
+		gd.Set(it.p, c)
+	}
+}
+
+// LineFunc calls fn, in order, for the positions of the line from p to q
+// that are contained in the grid, as given by Line, along with their cell
+// value. It stops as soon as fn returns false, in which case it returns the
+// current position and true. Otherwise, it returns q and false. It may be
+// used to follow a projectile until it hits an obstacle.
+func (gd Grid[T]) LineFunc(p, q Point, fn func(Point, T) bool) (Point, bool) {
+	it := Line(p, q)
+	for it.Next() {
+		if !gd.Contains(it.p) {
+			continue
+		}
+		if !fn(it.p, gd.At(it.p)) {
+			return it.p, true
+		}
+	}
+	return q, false
+}
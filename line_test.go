@@ -0,0 +1,108 @@
+package grid
+
+import "testing"
+
+func linePoints(it LineIterator) []Point {
+	ps := []Point{}
+	for p := range it.Points() {
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+func TestLine(t *testing.T) {
+	ps := linePoints(Line(Point{0, 0}, Point{5, 2}))
+	want := []Point{{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 2}, {5, 2}}
+	if len(ps) != len(want) {
+		t.Fatalf("bad line: %v", ps)
+	}
+	for i := range want {
+		if ps[i] != want[i] {
+			t.Errorf("bad line: %v", ps)
+			break
+		}
+	}
+	ps = linePoints(Line(Point{3, 3}, Point{3, 3}))
+	if len(ps) != 1 || ps[0] != (Point{3, 3}) {
+		t.Errorf("bad single point line: %v", ps)
+	}
+	it := Line(Point{0, 0}, Point{5, 2})
+	it.Next()
+	it.Next()
+	n := 0
+	for range it.Points() {
+		n++
+	}
+	if n != 6 || it.P() != (Point{1, 0}) {
+		t.Errorf("bad points from started iterator: %d (at %v)", n, it.P())
+	}
+}
+
+func TestLineProperties(t *testing.T) {
+	for i := 0; i < 500; i++ {
+		p := Point{randInt(40) - 20, randInt(40) - 20}
+		q := Point{randInt(40) - 20, randInt(40) - 20}
+		for _, it := range []LineIterator{Line(p, q), SymmetricLine(p, q)} {
+			ps := linePoints(it)
+			if len(ps) != it.Len() || len(ps) != p.Chebyshev(q)+1 {
+				t.Errorf("bad length for line %v-%v: %d", p, q, len(ps))
+			}
+			if ps[0] != p || ps[len(ps)-1] != q {
+				t.Errorf("bad endpoints for line %v-%v: %v", p, q, ps)
+			}
+			for j := 1; j < len(ps); j++ {
+				if ps[j].Chebyshev(ps[j-1]) != 1 {
+					t.Errorf("non connected line %v-%v: %v", p, q, ps)
+				}
+			}
+		}
+		ps := linePoints(SymmetricLine(p, q))
+		rps := linePoints(SymmetricLine(q, p))
+		for j := range ps {
+			if ps[j] != rps[len(rps)-1-j] {
+				t.Errorf("non symmetric line %v-%v: %v vs %v", p, q, ps, rps)
+				break
+			}
+		}
+	}
+}
+
+func TestLineIteratorReset(t *testing.T) {
+	it := Line(Point{1, 2}, Point{-3, 9})
+	n := 0
+	for it.Next() {
+		n++
+	}
+	it.Reset()
+	if !it.Next() || it.P() != (Point{1, 2}) {
+		t.Errorf("bad reset: %v", it.P())
+	}
+	if n != 8 {
+		t.Errorf("bad count: %d", n)
+	}
+}
+
+func TestGridDrawLine(t *testing.T) {
+	gd := NewGrid[int](10, 10)
+	gd.DrawLine(Point{-5, 5}, Point{20, 5}, 1)
+	gd.Iter(func(p Point, c int) {
+		if p.Y == 5 && c != 1 || p.Y != 5 && c != 0 {
+			t.Errorf("bad cell %d at %v", c, p)
+		}
+	})
+	gd.Set(Point{7, 5}, 2)
+	n := 0
+	q, ok := gd.LineFunc(Point{-3, 5}, Point{12, 5}, func(p Point, c int) bool {
+		n++
+		return c != 2
+	})
+	if !ok || q != (Point{7, 5}) || n != 8 {
+		t.Errorf("bad LineFunc stop: %v %v %d", q, ok, n)
+	}
+	q, ok = gd.LineFunc(Point{0, 0}, Point{9, 9}, func(p Point, c int) bool {
+		return c != 2
+	})
+	if ok || q != (Point{9, 9}) {
+		t.Errorf("bad LineFunc: %v %v", q, ok)
+	}
+}
@@ -0,0 +1,230 @@
+package grid
+
+// CrossElement returns a new cross-shaped structuring element of radius r,
+// made of the origin and the offsets at Manhattan distance at most r, for
+// use with Morphology methods.
+func CrossElement(r int) Stencil {
+	return append(Stencil{{0, 0}}, VonNeumannRadius(r)...)
+}
+
+// SquareElement returns a new square structuring element of radius r, made
+// of the origin and the offsets at Chebyshev distance at most r, for use
+// with Morphology methods.
+func SquareElement(r int) Stencil {
+	return append(Stencil{{0, 0}}, MooreRadius(r)...)
+}
+
+// GridElement returns a new structuring element made of the offsets of the
+// true cells of gd relative to its center position, gd.Size().Div(2), in
+// row-major order.
+func GridElement(gd Grid[bool]) Stencil {
+	c := gd.Size().Div(2)
+	se := Stencil{}
+	gd.Iter(func(p Point, in bool) {
+		if in {
+			se = append(se, p.Sub(c))
+		}
+	})
+	return se
+}
+
+// Morphology applies binary morphological operations to boolean grids, such
+// as erosion and dilation, with a structuring element given as a stencil of
+// offsets that usually contains the origin.
+//
+// The source is read into an internal buffer before the destination is
+// written, so that the destination and source grids may overlap. Only the
+// positions in both the destination and the source are written.
+//
+// A Morphology keeps internal buffers across calls, so that processing
+// grids of the same size does not allocate. The zero value is ready to use,
+// treating positions out of the source grid as false. A Morphology should
+// not be used concurrently.
+type Morphology struct {
+	// Border is the value of positions out of the source grid.
+	Border bool
+
+	in  []bool // source values
+	tmp []bool // intermediate values
+	w   int    // source width
+	h   int    // source height
+}
+
+// Erode computes into dst the erosion of src by structuring element se: a
+// position is true if p+d is true in src for all offsets d of se.
+func (m *Morphology) Erode(dst, src Grid[bool], se Stencil) {
+	m.load(src)
+	m.erode(m.tmp, m.in, se)
+	m.store(dst, src, m.tmp)
+}
+
+// Dilate computes into dst the dilation of src by structuring element se: a
+// position p is true if p-d is true in src for some offset d of se.
+func (m *Morphology) Dilate(dst, src Grid[bool], se Stencil) {
+	m.load(src)
+	m.dilate(m.tmp, m.in, se)
+	m.store(dst, src, m.tmp)
+}
+
+// Open computes into dst the opening of src by structuring element se,
+// that is the dilation of its erosion. It removes small isolated regions and
+// thin protrusions.
+func (m *Morphology) Open(dst, src Grid[bool], se Stencil) {
+	m.load(src)
+	m.erode(m.tmp, m.in, se)
+	m.dilate(m.in, m.tmp, se)
+	m.store(dst, src, m.in)
+}
+
+// Close computes into dst the closing of src by structuring element se,
+// that is the erosion of its dilation. It fills small holes and narrow
+// gaps.
+func (m *Morphology) Close(dst, src Grid[bool], se Stencil) {
+	m.load(src)
+	m.dilate(m.tmp, m.in, se)
+	m.erode(m.in, m.tmp, se)
+	m.store(dst, src, m.in)
+}
+
+// HitOrMiss computes into dst the hit-or-miss transform of src: a position
+// p is true if p+d is true in src for all offsets d of hit, and false for
+// all offsets d of miss. It can be used to detect specific patterns, such
+// as corners or dead ends.
+func (m *Morphology) HitOrMiss(dst, src Grid[bool], hit, miss Stencil) {
+	m.load(src)
+	for y := 0; y < m.h; y++ {
+		for x := 0; x < m.w; x++ {
+			v := true
+			for _, d := range hit {
+				if !m.at(m.in, x+d.X, y+d.Y) {
+					v = false
+					break
+				}
+			}
+			for _, d := range miss {
+				if !v {
+					break
+				}
+				if m.at(m.in, x+d.X, y+d.Y) {
+					v = false
+				}
+			}
+			m.tmp[y*m.w+x] = v
+		}
+	}
+	m.store(dst, src, m.tmp)
+}
+
+// Thin computes into dst the skeleton of src using the Zhang-Suen thinning
+// algorithm: true regions are iteratively thinned down to 8-connected lines
+// of width one, preserving their connectivity. It can be used to extract
+// corridor center lines from cave-like maps.
+func (m *Morphology) Thin(dst, src Grid[bool]) {
+	m.load(src)
+	var nbs [8]bool
+	for changed := true; changed; {
+		changed = false
+		for step := 0; step < 2; step++ {
+			copy(m.tmp, m.in)
+			for y := 0; y < m.h; y++ {
+				for x := 0; x < m.w; x++ {
+					if !m.tmp[y*m.w+x] {
+						continue
+					}
+					// neighbors in clockwise order starting from north
+					n := 0
+					for i, d := range Moore {
+						nbs[i] = m.at(m.tmp, x+d.X, y+d.Y)
+						if nbs[i] {
+							n++
+						}
+					}
+					if n < 2 || n > 6 {
+						continue
+					}
+					transitions := 0
+					for i := range nbs {
+						if !nbs[i] && nbs[(i+1)%8] {
+							transitions++
+						}
+					}
+					if transitions != 1 {
+						continue
+					}
+					north, east, south, west := nbs[0], nbs[2], nbs[4], nbs[6]
+					if step == 0 && (north && east && south || east && south && west) ||
+						step == 1 && (north && east && west || north && south && west) {
+						continue
+					}
+					m.in[y*m.w+x] = false
+					changed = true
+				}
+			}
+		}
+	}
+	m.store(dst, src, m.in)
+}
+
+// load reads the values of src into the source buffer, and prepares the
+// intermediate buffer.
+func (m *Morphology) load(src Grid[bool]) {
+	max := src.Size()
+	m.w, m.h = max.X, max.Y
+	n := m.w * m.h
+	if cap(m.in) < n {
+		m.in = make([]bool, n)
+		m.tmp = make([]bool, n)
+	}
+	m.in, m.tmp = m.in[:n], m.tmp[:n]
+	src.Iter(func(p Point, v bool) {
+		m.in[p.Y*m.w+p.X] = v
+	})
+}
+
+// store writes the values of buf into dst.
+func (m *Morphology) store(dst, src Grid[bool], buf []bool) {
+	max := dst.Range().Intersect(src.Range()).Size()
+	dst = dst.Slice(Range{Max: max})
+	dst.Map(func(p Point, _ bool) bool {
+		return buf[p.Y*m.w+p.X]
+	})
+}
+
+// at returns the value of buf at position (x, y), or Border if it is out of
+// the grid.
+func (m *Morphology) at(buf []bool, x, y int) bool {
+	if x < 0 || x >= m.w || y < 0 || y >= m.h {
+		return m.Border
+	}
+	return buf[y*m.w+x]
+}
+
+func (m *Morphology) erode(out, in []bool, se Stencil) {
+	for y := 0; y < m.h; y++ {
+		for x := 0; x < m.w; x++ {
+			v := true
+			for _, d := range se {
+				if !m.at(in, x+d.X, y+d.Y) {
+					v = false
+					break
+				}
+			}
+			out[y*m.w+x] = v
+		}
+	}
+}
+
+func (m *Morphology) dilate(out, in []bool, se Stencil) {
+	for y := 0; y < m.h; y++ {
+		for x := 0; x < m.w; x++ {
+			v := false
+			for _, d := range se {
+				if m.at(in, x-d.X, y-d.Y) {
+					v = true
+					break
+				}
+			}
+			out[y*m.w+x] = v
+		}
+	}
+}
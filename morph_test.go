@@ -0,0 +1,120 @@
+package grid
+
+import "testing"
+
+func countTrue(gd Grid[bool]) int {
+	n := 0
+	for v := range gd.Values() {
+		if v {
+			n++
+		}
+	}
+	return n
+}
+
+func TestElements(t *testing.T) {
+	if len(CrossElement(1)) != 5 || len(SquareElement(1)) != 9 || len(SquareElement(2)) != 25 {
+		t.Errorf("bad element sizes")
+	}
+	se := GridElement(lifeGrid(
+		".#.",
+		"##.",
+		"...",
+	))
+	want := Stencil{{0, -1}, {-1, 0}, {0, 0}}
+	if len(se) != len(want) {
+		t.Fatalf("bad grid element: %v", se)
+	}
+	for i := range se {
+		if se[i] != want[i] {
+			t.Errorf("bad grid element: %v", se)
+		}
+	}
+}
+
+func TestErodeDilate(t *testing.T) {
+	src := lifeGrid(
+		".......",
+		".####..",
+		".####..",
+		".####.#",
+		".......",
+	)
+	dst := NewGrid[bool](7, 5)
+	var m Morphology
+	m.Erode(dst, src, SquareElement(1))
+	if countTrue(dst) != 2 || !dst.At(Point{2, 2}) || !dst.At(Point{3, 2}) {
+		t.Errorf("bad erosion: %d", countTrue(dst))
+	}
+	m.Dilate(dst, dst, SquareElement(1))
+	if countTrue(dst) != 12 || dst.At(Point{6, 3}) {
+		t.Errorf("bad dilation: %d", countTrue(dst))
+	}
+	m.Open(dst, src, SquareElement(1))
+	if countTrue(dst) != 12 || dst.At(Point{6, 3}) {
+		t.Errorf("bad opening: %d", countTrue(dst))
+	}
+	holes := lifeGrid(
+		"#####",
+		"#.###",
+		"#####",
+	)
+	closed := NewGrid[bool](5, 3)
+	m.Border = true
+	m.Close(closed, holes, CrossElement(1))
+	if countTrue(closed) != 15 {
+		t.Errorf("bad closing: %d", countTrue(closed))
+	}
+	m.Border = false
+	m.Erode(closed, holes, CrossElement(1))
+	if countTrue(closed) != 1 || !closed.At(Point{3, 1}) {
+		t.Errorf("bad erosion with false border: %d", countTrue(closed))
+	}
+	// destination slice smaller than source
+	big := NewGrid[bool](10, 10)
+	sub := big.Slice(NewRange(1, 1, 3, 3))
+	m.Dilate(sub, src, CrossElement(1))
+	if countTrue(big) != 3 || !big.At(Point{2, 1}) || !big.At(Point{1, 2}) || !big.At(Point{2, 2}) {
+		t.Errorf("bad dilation into slice: %d", countTrue(big))
+	}
+}
+
+func TestHitOrMiss(t *testing.T) {
+	src := lifeGrid(
+		"#....",
+		"...#.",
+		"..##.",
+	)
+	dst := NewGrid[bool](5, 3)
+	var m Morphology
+	m.HitOrMiss(dst, src, Stencil{{0, 0}}, Moore)
+	if countTrue(dst) != 1 || !dst.At(Point{0, 0}) {
+		t.Errorf("bad isolated points detection")
+	}
+}
+
+func TestThin(t *testing.T) {
+	src := lifeGrid(
+		"..........",
+		".########.",
+		".########.",
+		".########.",
+		"..........",
+	)
+	dst := NewGrid[bool](10, 5)
+	var m Morphology
+	m.Thin(dst, src)
+	n := countTrue(dst)
+	if n == 0 || n > 8 {
+		t.Errorf("bad thinning count: %d", n)
+	}
+	for p, v := range dst.All() {
+		if v && p.Y != 2 {
+			t.Errorf("skeleton not centered: %v", p)
+		}
+	}
+	_, comps := Label(dst, Conn8, func(v bool) bool { return v })
+	if len(comps) != 1 {
+		t.Errorf("skeleton not connected: %d components", len(comps))
+	}
+}
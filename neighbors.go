@@ -0,0 +1,102 @@
+package grid
+
+// Stencil represents a neighborhood as a list of offsets relative to a
+// central position. Any list of offsets can be used as a custom stencil, for
+// example the knight moves of chess:
+//
+//	knight := grid.Stencil{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+//
+// Stencils should not be modified while in use.
+type Stencil []Point
+
+// Predefined stencils for the usual neighborhoods of radius one. They should
+// not be modified.
+var (
+	// Cardinal is the 4-connected neighborhood (north, east, south,
+	// west), also known as von Neumann neighborhood of radius 1.
+	Cardinal = Stencil{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+	// Diagonal is the neighborhood of the four diagonal positions.
+	Diagonal = Stencil{{1, -1}, {1, 1}, {-1, 1}, {-1, -1}}
+	// Moore is the 8-connected neighborhood, made of both Cardinal and
+	// Diagonal positions.
+	Moore = Stencil{{0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}}
+)
+
+// VonNeumannRadius returns a new stencil with all the offsets at Manhattan
+// distance between 1 and r, in row-major order.
+func VonNeumannRadius(r int) Stencil {
+	st := Stencil{}
+	for y := -r; y <= r; y++ {
+		for x := -r; x <= r; x++ {
+			d := abs(x) + abs(y)
+			if d > 0 && d <= r {
+				st = append(st, Point{x, y})
+			}
+		}
+	}
+	return st
+}
+
+// MooreRadius returns a new stencil with all the offsets at Chebyshev
+// distance between 1 and r, that is, the (2r+1)x(2r+1) square around the
+// center excluding the center, in row-major order.
+func MooreRadius(r int) Stencil {
+	st := Stencil{}
+	for y := -r; y <= r; y++ {
+		for x := -r; x <= r; x++ {
+			if x != 0 || y != 0 {
+				st = append(st, Point{x, y})
+			}
+		}
+	}
+	return st
+}
+
+// Neighbors appends to buf[:0] the positions p+d, for each offset d in the
+// stencil, that are within the range, and returns the updated slice. Using a
+// buffer of sufficient capacity avoids any allocation.
+func (rg Range) Neighbors(buf []Point, p Point, st Stencil) []Point {
+	buf = buf[:0]
+	for _, d := range st {
+		q := p.Add(d)
+		if q.In(rg) {
+			buf = append(buf, q)
+		}
+	}
+	return buf
+}
+
+// Neighbors appends to buf[:0] the relative positions p+d, for each offset d
+// in the stencil, that are contained in the grid, and returns the updated
+// slice. It is equivalent to gd.Range().Neighbors(buf, p, st).
+//
+// The following pattern iterates on the 4-connected neighbors of p without
+// allocating in the loop:
+//
+//	var nbs []grid.Point
+//	// ...
+//	nbs = gd.Neighbors(nbs, p, grid.Cardinal)
+//	for _, q := range nbs {
+//		// do something with gd.At(q)
+//	}
+func (gd Grid[T]) Neighbors(buf []Point, p Point, st Stencil) []Point {
+	return gd.Range().Neighbors(buf, p, st)
+}
+
+// NeighborValues appends to buf[:0] the cell values at positions p+d, for
+// each offset d in the stencil, that are contained in the grid, and returns
+// the updated slice. Values are appended in stencil order.
+func (gd Grid[T]) NeighborValues(buf []T, p Point, st Stencil) []T {
+	buf = buf[:0]
+	if gd.ug == nil {
+		return buf
+	}
+	w := gd.ug.Width
+	for _, d := range st {
+		q := p.Add(d).Add(gd.rg.Min)
+		if q.In(gd.rg) {
+			buf = append(buf, gd.ug.Cells[q.Y*w+q.X])
+		}
+	}
+	return buf
+}
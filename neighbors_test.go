@@ -0,0 +1,97 @@
+package grid
+
+import "testing"
+
+func TestStencils(t *testing.T) {
+	if len(VonNeumannRadius(1)) != 4 {
+		t.Errorf("bad von Neumann radius 1: %v", VonNeumannRadius(1))
+	}
+	if len(VonNeumannRadius(2)) != 12 {
+		t.Errorf("bad von Neumann radius 2: %v", VonNeumannRadius(2))
+	}
+	if len(MooreRadius(1)) != 8 {
+		t.Errorf("bad Moore radius 1: %v", MooreRadius(1))
+	}
+	if len(MooreRadius(2)) != 24 {
+		t.Errorf("bad Moore radius 2: %v", MooreRadius(2))
+	}
+	if len(VonNeumannRadius(0)) != 0 || len(MooreRadius(0)) != 0 {
+		t.Errorf("non empty radius 0 stencil")
+	}
+	moore := map[Point]bool{}
+	for _, d := range Moore {
+		moore[d] = true
+	}
+	for _, d := range append(append(Stencil{}, Cardinal...), Diagonal...) {
+		if !moore[d] {
+			t.Errorf("%v not in Moore", d)
+		}
+	}
+}
+
+func TestGridNeighbors(t *testing.T) {
+	gd := NewGrid[int](10, 10)
+	gd.FillFunc(func(p Point) int {
+		return 100*p.X + p.Y
+	})
+	slice := gd.Slice(NewRange(2, 2, 6, 6))
+	var nbs []Point
+	nbs = slice.Neighbors(nbs, Point{1, 1}, Moore)
+	if len(nbs) != 8 {
+		t.Errorf("bad number of neighbors: %v", nbs)
+	}
+	nbs = slice.Neighbors(nbs, Point{0, 0}, Moore)
+	if len(nbs) != 3 {
+		t.Errorf("bad number of corner neighbors: %v", nbs)
+	}
+	nbs = slice.Neighbors(nbs, Point{0, 2}, Cardinal)
+	if len(nbs) != 3 {
+		t.Errorf("bad number of border neighbors: %v", nbs)
+	}
+	for _, q := range nbs {
+		if !slice.Contains(q) {
+			t.Errorf("out of range neighbor: %v", q)
+		}
+	}
+	var vals []int
+	vals = slice.NeighborValues(vals, Point{0, 2}, Cardinal)
+	if len(vals) != len(nbs) {
+		t.Fatalf("bad number of values: %v", vals)
+	}
+	for i, q := range nbs {
+		if vals[i] != slice.At(q) {
+			t.Errorf("bad value %d for %v", vals[i], q)
+		}
+	}
+	var nilgd Grid[int]
+	if len(nilgd.NeighborValues(vals, Point{}, Moore)) != 0 {
+		t.Errorf("non empty values for nil grid")
+	}
+}
+
+func TestRangeNeighbors(t *testing.T) {
+	rg := NewRange(-2, -2, 3, 3)
+	nbs := rg.Neighbors(nil, Point{}, VonNeumannRadius(2))
+	if len(nbs) != 12 {
+		t.Errorf("bad number of neighbors: %v", nbs)
+	}
+	nbs = rg.Neighbors(nbs, Point{-2, -2}, VonNeumannRadius(2))
+	if len(nbs) != 5 {
+		t.Errorf("bad number of corner neighbors: %v", nbs)
+	}
+}
+
+func BenchmarkGridNeighbors(b *testing.B) {
+	gd := NewGrid[int](80, 24)
+	var nbs []Point
+	for i := 0; i < b.N; i++ {
+		n := 0
+		max := gd.Size()
+		for y := 0; y < max.Y; y++ {
+			for x := 0; x < max.X; x++ {
+				nbs = gd.Neighbors(nbs, Point{x, y}, Moore)
+				n += len(nbs)
+			}
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package grid
+
+// Order represents a traversal order for a GridIterator.
+type Order int
+
+// Available traversal orders. In all cases, the iterator visits each position
+// of the grid exactly once.
+const (
+	// RowMajor visits lines from top to bottom, and each line from left
+	// to right. It is the default order, and the most efficient one.
+	RowMajor Order = iota
+	// ColumnMajor visits columns from left to right, and each column from
+	// top to bottom.
+	ColumnMajor
+	// ReverseRowMajor visits lines from bottom to top, and each line from
+	// right to left.
+	ReverseRowMajor
+	// Serpentine visits lines from top to bottom, alternating direction
+	// on each line (boustrophedon order): even lines are visited from left
+	// to right, and odd lines from right to left.
+	Serpentine
+	// Spiral visits positions in a clockwise square spiral going outward
+	// from a center, by increasing Chebyshev distance. Each ring starts
+	// just right of the previous ring's upper-right corner.
+	Spiral
+)
+
+// String returns the name of the order.
+func (o Order) String() string {
+	switch o {
+	case RowMajor:
+		return "RowMajor"
+	case ColumnMajor:
+		return "ColumnMajor"
+	case ReverseRowMajor:
+		return "ReverseRowMajor"
+	case Serpentine:
+		return "Serpentine"
+	case Spiral:
+		return "Spiral"
+	default:
+		return "Order(?)"
+	}
+}
+
+// IteratorOrder is like Iterator, but the returned iterator visits the grid
+// positions using the given traversal order. For the Spiral order, the
+// center is gd.Size().Div(2).
+//
+// Calling SetP on the returned iterator makes Next continue from the new
+// position according to the traversal order.
+func (gd Grid[T]) IteratorOrder(order Order) GridIterator[T] {
+	return gd.iteratorOrder(order, gd.Size().Div(2))
+}
+
+// SpiralIterator returns an iterator visiting the grid positions in Spiral
+// order around the given center, which is a position relative to the grid.
+// The center may be out of the grid's range, in which case only positions
+// within the grid are visited.
+func (gd Grid[T]) SpiralIterator(c Point) GridIterator[T] {
+	return gd.iteratorOrder(Spiral, c)
+}
+
+func (gd Grid[T]) iteratorOrder(order Order, c Point) GridIterator[T] {
+	it := gd.Iterator()
+	if gd.ug == nil {
+		return it
+	}
+	it.order = order
+	it.c = c
+	it.Reset()
+	return it
+}
+
+// reset implements Reset for orders other than RowMajor.
+func (it *GridIterator[T]) reset() {
+	switch it.order {
+	case ColumnMajor:
+		it.p = Point{0, -1}
+		it.i = (it.rg.Min.Y-1)*it.w + it.rg.Min.X
+	case ReverseRowMajor:
+		it.p = it.max.Shift(1, 0)
+		it.i = (it.rg.Min.Y+it.max.Y)*it.w + it.rg.Min.X + it.max.X + 1
+	case Serpentine:
+		it.p = Point{-1, 0}
+		it.i = it.rg.Min.Y*it.w + it.rg.Min.X - 1
+	case Spiral:
+		// out of range position: the first call to Next starts at the
+		// center.
+		it.p = Point{-1, -1}
+	}
+}
+
+// next implements Next for orders other than RowMajor.
+func (it *GridIterator[T]) next() bool {
+	if it.max.X < 0 || it.max.Y < 0 {
+		return false
+	}
+	switch it.order {
+	case ColumnMajor:
+		if it.p.Y < it.max.Y {
+			it.p.Y++
+			it.i += it.w
+			return true
+		}
+		if it.p.X < it.max.X {
+			it.p.X++
+			it.p.Y = 0
+			it.i += 1 - it.max.Y*it.w
+			return true
+		}
+	case ReverseRowMajor:
+		if it.p.X > 0 {
+			it.p.X--
+			it.i--
+			return true
+		}
+		if it.p.Y > 0 {
+			it.p.Y--
+			it.p.X = it.max.X
+			it.i -= it.nlstep
+			return true
+		}
+	case Serpentine:
+		if it.p.Y%2 == 0 {
+			if it.p.X < it.max.X {
+				it.p.X++
+				it.i++
+				return true
+			}
+		} else if it.p.X > 0 {
+			it.p.X--
+			it.i--
+			return true
+		}
+		if it.p.Y < it.max.Y {
+			it.p.Y++
+			it.i += it.w
+			return true
+		}
+	case Spiral:
+		return it.nextSpiral()
+	}
+	return false
+}
+
+// nextSpiral advances the iterator to the next position in Spiral order. The
+// next position is computed from the current one and the center only, and
+// whole out of range parts of the spiral are skipped, so that iterating over
+// a thin grid is done in linear time.
+func (it *GridIterator[T]) nextSpiral() bool {
+	rg := Range{Max: it.max.Shift(1, 1)}
+	c := it.c
+	p := it.p
+	if !p.In(rg) {
+		if c.In(rg) {
+			it.setp(c)
+			return true
+		}
+		p = c
+	}
+	kmax := max(abs(c.X), abs(it.max.X-c.X), abs(c.Y), abs(it.max.Y-c.Y))
+	for {
+		d := p.Sub(c)
+		k := max(abs(d.X), abs(d.Y))
+		var dir, end Point // direction and end of current side
+		switch {
+		case k == 0 || d.X == k && d.Y == -k:
+			// start of next ring
+			dir = Point{1, 0}
+			end = p.Add(dir)
+			k++
+		case d.Y == -k:
+			dir = Point{1, 0}
+			end = c.Shift(k, -k)
+		case d.Y == k && d.X > -k:
+			dir = Point{-1, 0}
+			end = c.Shift(-k, k)
+		case d.X == k:
+			dir = Point{0, 1}
+			end = c.Shift(k, k)
+		default:
+			dir = Point{0, -1}
+			end = c.Shift(-k, -k)
+		}
+		if k > kmax {
+			return false
+		}
+		if q, ok := segmentFirstIn(p.Add(dir), end, rg); ok {
+			it.setp(q)
+			return true
+		}
+		p = end
+	}
+}
+
+// segmentFirstIn returns the first position within rg of the horizontal or
+// vertical segment going from a to b, both included.
+func segmentFirstIn(a, b Point, rg Range) (Point, bool) {
+	seg := Range{
+		Min: Point{min(a.X, b.X), min(a.Y, b.Y)},
+		Max: Point{max(a.X, b.X) + 1, max(a.Y, b.Y) + 1},
+	}
+	seg = seg.Intersect(rg)
+	if seg.Empty() {
+		return Point{}, false
+	}
+	if a.X <= b.X && a.Y <= b.Y {
+		return seg.Min, true
+	}
+	return seg.Max.Shift(-1, -1), true
+}
+
+// setp sets the iterator's position to the relative in range position p.
+func (it *GridIterator[T]) setp(p Point) {
+	it.p = p
+	it.i = (p.Y+it.rg.Min.Y)*it.w + p.X + it.rg.Min.X
+}
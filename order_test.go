@@ -0,0 +1,141 @@
+package grid
+
+import "testing"
+
+func iterPoints(it GridIterator[int]) []Point {
+	ps := []Point{}
+	for it.Next() {
+		ps = append(ps, it.P())
+	}
+	return ps
+}
+
+func testOrderVisitsAll(t *testing.T, gd Grid[int], it GridIterator[int]) {
+	t.Helper()
+	gd.FillFunc(func(p Point) int {
+		return 1000*p.X + p.Y
+	})
+	seen := map[Point]bool{}
+	for it.Next() {
+		p := it.P()
+		if seen[p] {
+			t.Errorf("%v visited twice", p)
+		}
+		seen[p] = true
+		if it.V() != gd.At(p) {
+			t.Errorf("bad value %d at %v: expected %d", it.V(), p, gd.At(p))
+		}
+		it.SetV(-1)
+	}
+	max := gd.Size()
+	if len(seen) != max.X*max.Y {
+		t.Errorf("bad count %d for size %v", len(seen), max)
+	}
+	gd.Iter(func(p Point, c int) {
+		if c != -1 {
+			t.Errorf("bad SetV at %v", p)
+		}
+	})
+}
+
+func TestIteratorOrderAll(t *testing.T) {
+	gd := NewGrid[int](20, 15)
+	slices := []Grid[int]{
+		gd,
+		gd.Slice(NewRange(2, 3, 9, 7)),
+		gd.Slice(NewRange(2, 3, 9, 4)),
+		gd.Slice(NewRange(2, 3, 3, 12)),
+		gd.Slice(NewRange(5, 5, 6, 6)),
+		gd.Slice(NewRange(0, 0, 12, 2)),
+	}
+	for _, o := range []Order{RowMajor, ColumnMajor, ReverseRowMajor, Serpentine, Spiral} {
+		for _, slice := range slices {
+			testOrderVisitsAll(t, slice, slice.IteratorOrder(o))
+		}
+	}
+	for _, c := range []Point{{0, 0}, {-3, 4}, {30, -2}, {6, 6}} {
+		for _, slice := range slices {
+			testOrderVisitsAll(t, slice, slice.SpiralIterator(c))
+		}
+	}
+}
+
+func TestIteratorOrderSequences(t *testing.T) {
+	gd := NewGrid[int](3, 2)
+	tests := []struct {
+		order Order
+		want  []Point
+	}{
+		{ColumnMajor, []Point{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {2, 0}, {2, 1}}},
+		{ReverseRowMajor, []Point{{2, 1}, {1, 1}, {0, 1}, {2, 0}, {1, 0}, {0, 0}}},
+		{Serpentine, []Point{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {1, 1}, {0, 1}}},
+		{Spiral, []Point{{1, 1}, {2, 1}, {0, 1}, {0, 0}, {1, 0}, {2, 0}}},
+	}
+	for _, tt := range tests {
+		got := iterPoints(gd.IteratorOrder(tt.order))
+		if len(got) != len(tt.want) {
+			t.Errorf("%v: bad sequence %v", tt.order, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%v: bad sequence %v", tt.order, got)
+				break
+			}
+		}
+	}
+}
+
+func TestIteratorSpiral(t *testing.T) {
+	gd := NewGrid[int](9, 9)
+	c := Point{4, 4}
+	it := gd.SpiralIterator(c)
+	prev := -1
+	n := 0
+	for it.Next() {
+		d := it.P().Sub(c)
+		k := max(abs(d.X), abs(d.Y))
+		if k < prev {
+			t.Errorf("non increasing distance at %v", it.P())
+		}
+		if n == 1 && it.P() != c.Shift(1, 0) {
+			t.Errorf("bad second position: %v", it.P())
+		}
+		prev = k
+		n++
+	}
+	if n != 81 {
+		t.Errorf("bad count: %d", n)
+	}
+	it.Reset()
+	if !it.Next() || it.P() != c {
+		t.Errorf("bad reset: %v", it.P())
+	}
+}
+
+func TestIteratorOrderEmpty(t *testing.T) {
+	gd := NewGrid[int](0, 5)
+	for _, o := range []Order{ColumnMajor, ReverseRowMajor, Serpentine, Spiral} {
+		it := gd.IteratorOrder(o)
+		if it.Next() {
+			t.Errorf("%v: non empty iteration", o)
+		}
+	}
+	var gd2 Grid[int]
+	it := gd2.IteratorOrder(Spiral)
+	if it.Next() {
+		t.Errorf("non empty iteration for nil grid")
+	}
+}
+
+func BenchmarkGridIteratorSpiral(b *testing.B) {
+	gd := NewGrid[int](80, 24)
+	gd.Fill(1)
+	for i := 0; i < b.N; i++ {
+		n := 0
+		it := gd.IteratorOrder(Spiral)
+		for it.Next() {
+			n += it.V()
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package grid
+
+import "iter"
+
+// RangeSet represents an arbitrary set of positions as a list of disjoint
+// ranges. It is suited for tracking dirty regions or selections. The zero
+// value is an empty set ready to use.
+type RangeSet struct {
+	rgs []Range // disjoint non-empty ranges
+	tmp []Range // scratch buffer for Add
+}
+
+// Add adds the positions of range rg to the set.
+func (s *RangeSet) Add(rg Range) {
+	if rg.Empty() {
+		return
+	}
+	pieces := append(s.tmp[:0], rg)
+	for _, r := range s.rgs {
+		pieces = subtractRange(pieces, r)
+		if len(pieces) == 0 {
+			break
+		}
+	}
+	s.rgs = append(s.rgs, pieces...)
+	s.tmp = pieces[:0]
+}
+
+// Remove removes the positions of range rg from the set.
+func (s *RangeSet) Remove(rg Range) {
+	if rg.Empty() {
+		return
+	}
+	s.rgs = subtractRange(s.rgs, rg)
+}
+
+// subtractRange removes from rgs the positions in r, and returns the updated
+// slice. It works in place, appending new pieces at the end and discarding
+// emptied ranges afterwards.
+func subtractRange(rgs []Range, r Range) []Range {
+	n := len(rgs)
+	for i := 0; i < n; i++ {
+		if rgs[i].Overlaps(r) {
+			rgs = rgs[i].appendDifference(rgs, r)
+			rgs[i] = Range{}
+		}
+	}
+	j := 0
+	for _, rg := range rgs {
+		if !rg.Empty() {
+			rgs[j] = rg
+			j++
+		}
+	}
+	return rgs[:j]
+}
+
+// Clear removes all the positions from the set, keeping allocated memory for
+// reuse.
+func (s *RangeSet) Clear() {
+	s.rgs = s.rgs[:0]
+}
+
+// Contains reports whether position p is in the set.
+func (s *RangeSet) Contains(p Point) bool {
+	for _, rg := range s.rgs {
+		if p.In(rg) {
+			return true
+		}
+	}
+	return false
+}
+
+// Empty reports whether the set contains no positions.
+func (s *RangeSet) Empty() bool {
+	return len(s.rgs) == 0
+}
+
+// Area returns the number of positions in the set.
+func (s *RangeSet) Area() int {
+	n := 0
+	for _, rg := range s.rgs {
+		sz := rg.Size()
+		n += sz.X * sz.Y
+	}
+	return n
+}
+
+// Bounds returns the smallest range containing all the positions of the set,
+// or the zero range if the set is empty.
+func (s *RangeSet) Bounds() Range {
+	if len(s.rgs) == 0 {
+		return Range{}
+	}
+	bounds := s.rgs[0]
+	for _, rg := range s.rgs[1:] {
+		bounds = bounds.Union(rg)
+	}
+	return bounds
+}
+
+// Len returns the number of disjoint ranges in the set.
+func (s *RangeSet) Len() int {
+	return len(s.rgs)
+}
+
+// Normalize merges adjacent ranges of the set that share a whole side,
+// reducing the number of ranges without changing the set of positions.
+func (s *RangeSet) Normalize() {
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(s.rgs); i++ {
+			for j := i + 1; j < len(s.rgs); j++ {
+				if u, ok := mergeRanges(s.rgs[i], s.rgs[j]); ok {
+					s.rgs[i] = u
+					last := len(s.rgs) - 1
+					s.rgs[j] = s.rgs[last]
+					s.rgs = s.rgs[:last]
+					j--
+					merged = true
+				}
+			}
+		}
+	}
+}
+
+// mergeRanges returns the union of the two ranges, if it is a range.
+func mergeRanges(r1, r2 Range) (Range, bool) {
+	switch {
+	case r1.Min.X == r2.Min.X && r1.Max.X == r2.Max.X &&
+		(r1.Max.Y == r2.Min.Y || r2.Max.Y == r1.Min.Y):
+		return r1.Union(r2), true
+	case r1.Min.Y == r2.Min.Y && r1.Max.Y == r2.Max.Y &&
+		(r1.Max.X == r2.Min.X || r2.Max.X == r1.Min.X):
+		return r1.Union(r2), true
+	}
+	return Range{}, false
+}
+
+// Ranges returns an iterator over the disjoint ranges of the set. The set
+// should not be modified during the iteration.
+func (s *RangeSet) Ranges() iter.Seq[Range] {
+	return func(yield func(Range) bool) {
+		for _, rg := range s.rgs {
+			if !yield(rg) {
+				return
+			}
+		}
+	}
+}
+
+// Points returns an iterator over all the positions of the set. Positions are
+// yielded range by range, each range in row-major order. The set should not
+// be modified during the iteration.
+func (s *RangeSet) Points() iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, rg := range s.rgs {
+			for p := range rg.Points() {
+				if !yield(p) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package grid
+
+import "testing"
+
+func testRangeSetDisjoint(t *testing.T, s *RangeSet) {
+	t.Helper()
+	var rgs []Range
+	for rg := range s.Ranges() {
+		if rg.Empty() {
+			t.Errorf("empty range in set")
+		}
+		for _, r := range rgs {
+			if r.Overlaps(rg) {
+				t.Errorf("overlapping ranges: %v %v", r, rg)
+			}
+		}
+		rgs = append(rgs, rg)
+	}
+}
+
+func TestRangeSet(t *testing.T) {
+	var s RangeSet
+	s.Add(NewRange(0, 0, 10, 10))
+	s.Add(NewRange(5, 5, 15, 15))
+	testRangeSetDisjoint(t, &s)
+	if s.Area() != 175 {
+		t.Errorf("bad area: %d", s.Area())
+	}
+	if !s.Contains(Point{12, 12}) || !s.Contains(Point{2, 2}) || s.Contains(Point{12, 2}) {
+		t.Errorf("bad Contains")
+	}
+	if s.Bounds() != NewRange(0, 0, 15, 15) {
+		t.Errorf("bad bounds: %v", s.Bounds())
+	}
+	s.Remove(NewRange(4, 4, 12, 12))
+	testRangeSetDisjoint(t, &s)
+	if s.Area() != 175-60 {
+		t.Errorf("bad area after remove: %d", s.Area())
+	}
+	if s.Contains(Point{6, 6}) || !s.Contains(Point{13, 13}) {
+		t.Errorf("bad Contains after remove")
+	}
+	n := 0
+	for p := range s.Points() {
+		if !s.Contains(p) {
+			t.Errorf("bad point: %v", p)
+		}
+		n++
+	}
+	if n != s.Area() {
+		t.Errorf("bad number of points: %d", n)
+	}
+	s.Clear()
+	if !s.Empty() || s.Area() != 0 || s.Bounds() != (Range{}) {
+		t.Errorf("non empty set after Clear")
+	}
+}
+
+func TestRangeSetNormalize(t *testing.T) {
+	var s RangeSet
+	for x := 0; x < 10; x++ {
+		s.Add(NewRange(x, 0, x+1, 5))
+	}
+	s.Add(NewRange(0, 5, 10, 6))
+	if s.Len() != 11 {
+		t.Errorf("bad number of ranges: %d", s.Len())
+	}
+	s.Normalize()
+	if s.Len() != 1 {
+		t.Errorf("bad normalization: %d ranges", s.Len())
+	}
+	for rg := range s.Ranges() {
+		if rg != NewRange(0, 0, 10, 6) {
+			t.Errorf("bad normalized range: %v", rg)
+		}
+	}
+	s.Add(NewRange(0, 0, 10, 6))
+	if s.Len() != 1 {
+		t.Errorf("bad add of already contained range")
+	}
+}
@@ -0,0 +1,199 @@
+package grid
+
+import "math"
+
+// LOS reports whether there is a line of sight between positions from and to,
+// that is, whether none of the positions strictly between them on the line
+// given by SymmetricLine blocks. The end positions themselves are not
+// checked. The result does not depend on the order of from and to, but it
+// may occasionally differ from the visibility computed by FOV.
+func LOS(from, to Point, blocks func(Point) bool) bool {
+	it := SymmetricLine(from, to)
+	n := it.Len()
+	for i := 0; it.Next(); i++ {
+		if i > 0 && i < n-1 && blocks(it.p) {
+			return false
+		}
+	}
+	return true
+}
+
+// RayIterator represents a stateful iterator over the cells crossed by a ray
+// with fractional origin, using the Amanatides-Woo voxel traversal
+// algorithm. In continuous coordinates, the cell at position p covers the
+// square from (p.X, p.Y) included to (p.X+1, p.Y+1) excluded, so that (3.5,
+// 2.5) is the center of the cell (3,2). Successive positions are
+// 4-connected. When the ray passes exactly through a corner, the horizontal
+// neighbor is visited first.
+//
+// Rays are created with the Ray function, and used as follows:
+//
+//	it := grid.Ray(x, y, dx, dy)
+//	for it.Next() && it.T() <= maxT {
+//		// do something with it.P()
+//	}
+//
+// The ray is infinite, so the loop should be stopped explicitly, unless the
+// direction is zero, in which case only the origin cell is visited.
+type RayIterator struct {
+	x, y    float64   // origin
+	dx, dy  float64   // direction
+	p       Point     // current cell
+	step    Point     // cell step on each axis
+	tMaxX   float64   // ray parameter at next vertical boundary
+	tMaxY   float64   // ray parameter at next horizontal boundary
+	tDeltaX float64   // ray parameter increment between vertical boundaries
+	tDeltaY float64   // ray parameter increment between horizontal boundaries
+	t       float64   // ray parameter at current cell's entry
+	face    Direction // face through which the current cell was entered
+	started bool      // whether the origin cell has been visited
+}
+
+// Ray returns an iterator over the cells crossed by the ray starting at
+// (x, y) with direction (dx, dy). Points of the ray are given by (x + t*dx,
+// y + t*dy) for non-negative t, so t is the Euclidean distance from the
+// origin when the direction has unit length.
+func Ray(x, y, dx, dy float64) RayIterator {
+	it := RayIterator{x: x, y: y, dx: dx, dy: dy}
+	it.Reset()
+	return it
+}
+
+// Reset resets the iterator's state so that it can be used again.
+func (it *RayIterator) Reset() {
+	fx, fy := math.Floor(it.x), math.Floor(it.y)
+	it.p = Point{int(fx), int(fy)}
+	it.step = Point{}
+	it.tMaxX, it.tDeltaX = math.Inf(1), math.Inf(1)
+	it.tMaxY, it.tDeltaY = math.Inf(1), math.Inf(1)
+	switch {
+	case it.dx > 0:
+		it.step.X = 1
+		it.tDeltaX = 1 / it.dx
+		it.tMaxX = (fx + 1 - it.x) / it.dx
+	case it.dx < 0:
+		it.step.X = -1
+		it.tDeltaX = -1 / it.dx
+		it.tMaxX = (fx - it.x) / it.dx
+	}
+	switch {
+	case it.dy > 0:
+		it.step.Y = 1
+		it.tDeltaY = 1 / it.dy
+		it.tMaxY = (fy + 1 - it.y) / it.dy
+	case it.dy < 0:
+		it.step.Y = -1
+		it.tDeltaY = -1 / it.dy
+		it.tMaxY = (fy - it.y) / it.dy
+	}
+	it.t = 0
+	it.face = NoDirection
+	it.started = false
+}
+
+// Next advances the iterator to the next cell crossed by the ray. It returns
+// false only if the direction is zero and the origin cell has already been
+// visited.
+func (it *RayIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		return true
+	}
+	if it.step == (Point{}) {
+		return false
+	}
+	if it.tMaxX <= it.tMaxY {
+		it.t = it.tMaxX
+		it.tMaxX += it.tDeltaX
+		it.p.X += it.step.X
+		it.face = E
+		if it.step.X > 0 {
+			it.face = W
+		}
+	} else {
+		it.t = it.tMaxY
+		it.tMaxY += it.tDeltaY
+		it.p.Y += it.step.Y
+		it.face = S
+		if it.step.Y > 0 {
+			it.face = N
+		}
+	}
+	return true
+}
+
+// P returns the iterator's current cell position.
+func (it *RayIterator) P() Point {
+	return it.p
+}
+
+// T returns the ray parameter at which the ray entered the current cell, or
+// zero for the origin cell.
+func (it *RayIterator) T() float64 {
+	return it.t
+}
+
+// Entry returns the point at which the ray entered the current cell, or the
+// origin for the origin cell.
+func (it *RayIterator) Entry() (x, y float64) {
+	return it.x + it.t*it.dx, it.y + it.t*it.dy
+}
+
+// Face returns the face of the current cell through which the ray entered
+// it, as a cardinal direction: for example, W for a ray moving east. It
+// returns NoDirection for the origin cell.
+func (it *RayIterator) Face() Direction {
+	return it.face
+}
+
+// RayHit describes where a ray hit a cell.
+type RayHit struct {
+	P    Point     // hit cell
+	X, Y float64   // point where the ray entered the cell
+	T    float64   // ray parameter at (X, Y)
+	Face Direction // entry face, or NoDirection if the ray started in P
+}
+
+func (it *RayIterator) hit() RayHit {
+	x, y := it.Entry()
+	return RayHit{P: it.p, X: x, Y: y, T: it.t, Face: it.face}
+}
+
+// Raycast follows the ray starting at (x, y) with direction (dx, dy), as
+// given by Ray, until it enters a cell that blocks, in which case it returns
+// the hit description and true. It returns false if no blocking cell is
+// entered for ray parameters up to maxT.
+func Raycast(x, y, dx, dy, maxT float64, blocks func(Point) bool) (RayHit, bool) {
+	it := Ray(x, y, dx, dy)
+	for it.Next() && it.t <= maxT {
+		if blocks(it.p) {
+			return it.hit(), true
+		}
+	}
+	return RayHit{}, false
+}
+
+// RayFunc calls fn, in order, for the cells crossed by the ray starting at
+// (x, y) with direction (dx, dy), as given by Ray, that are contained in the
+// grid, along with their cell value, for ray parameters up to maxT. It stops
+// as soon as fn returns false, in which case it returns the hit description
+// and true. Otherwise, it returns false once the ray has left the grid or
+// exceeded maxT. Coordinates are relative to the grid.
+func (gd Grid[T]) RayFunc(x, y, dx, dy, maxT float64, fn func(Point, T) bool) (RayHit, bool) {
+	max := gd.Size()
+	it := Ray(x, y, dx, dy)
+	for it.Next() && it.t <= maxT {
+		if !gd.Contains(it.p) {
+			if it.p.X < 0 && it.step.X <= 0 || it.p.X >= max.X && it.step.X >= 0 ||
+				it.p.Y < 0 && it.step.Y <= 0 || it.p.Y >= max.Y && it.step.Y >= 0 {
+				// moving away from the grid
+				break
+			}
+			continue
+		}
+		if !fn(it.p, gd.At(it.p)) {
+			return it.hit(), true
+		}
+	}
+	return RayHit{}, false
+}
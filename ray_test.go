@@ -0,0 +1,116 @@
+package grid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLOS(t *testing.T) {
+	gd := newMaze(
+		"......",
+		"..#...",
+		"......",
+	)
+	blocks := func(p Point) bool { return gd.At(p) == '#' }
+	for _, tc := range []struct {
+		p, q Point
+		los  bool
+	}{
+		{Point{0, 1}, Point{5, 1}, false},
+		{Point{0, 0}, Point{5, 0}, true},
+		{Point{0, 1}, Point{2, 1}, true},
+		{Point{1, 1}, Point{1, 1}, true},
+		{Point{0, 0}, Point{4, 2}, false},
+	} {
+		if LOS(tc.p, tc.q, blocks) != tc.los || LOS(tc.q, tc.p, blocks) != tc.los {
+			t.Errorf("bad LOS between %v and %v", tc.p, tc.q)
+		}
+	}
+	for n := 0; n < 200; n++ {
+		p, q := Point{randInt(6), randInt(3)}, Point{randInt(6), randInt(3)}
+		if LOS(p, q, blocks) != LOS(q, p, blocks) {
+			t.Errorf("asymmetric LOS between %v and %v", p, q)
+		}
+	}
+}
+
+func TestRay(t *testing.T) {
+	it := Ray(0.5, 0.5, 2, 1)
+	want := []Point{{0, 0}, {1, 0}, {1, 1}, {2, 1}, {3, 1}}
+	faces := []Direction{NoDirection, W, N, W, W}
+	for i, p := range want {
+		if !it.Next() {
+			t.Fatalf("ray stopped")
+		}
+		if it.P() != p || it.Face() != faces[i] {
+			t.Errorf("bad cell %d: %v %v", i, it.P(), it.Face())
+		}
+		x, y := it.Entry()
+		if math.Abs(x-(0.5+2*it.T())) > 1e-9 || math.Abs(y-(0.5+it.T())) > 1e-9 {
+			t.Errorf("bad entry point: %v %v", x, y)
+		}
+	}
+	it.Reset()
+	if !it.Next() || it.P() != (Point{0, 0}) || it.T() != 0 {
+		t.Errorf("bad reset")
+	}
+	it = Ray(-1.5, 3.25, 0, 0)
+	if !it.Next() || it.P() != (Point{-2, 3}) || it.Next() {
+		t.Errorf("bad zero ray")
+	}
+	// each step of a ray moves to a 4-adjacent cell further along
+	for n := 0; n < 100; n++ {
+		x, y := float64(randInt(100))/10-5, float64(randInt(100))/10-5
+		a := float64(randInt(360)) * math.Pi / 180
+		it := Ray(x, y, math.Cos(a), math.Sin(a))
+		it.Next()
+		prev, pt := it.P(), 0.0
+		for k := 0; k < 20 && it.Next(); k++ {
+			if it.P().Manhattan(prev) != 1 || it.T() < pt {
+				t.Fatalf("bad step %v -> %v (t: %v -> %v)", prev, it.P(), pt, it.T())
+			}
+			cx, cy := it.Entry()
+			if cx < float64(it.P().X)-1e-9 || cx > float64(it.P().X+1)+1e-9 ||
+				cy < float64(it.P().Y)-1e-9 || cy > float64(it.P().Y+1)+1e-9 {
+				t.Fatalf("entry point (%v,%v) not on cell %v", cx, cy, it.P())
+			}
+			prev, pt = it.P(), it.T()
+		}
+	}
+}
+
+func TestRaycast(t *testing.T) {
+	gd := newMaze(
+		"......",
+		"......",
+		"....#.",
+		"......",
+	)
+	blocks := func(p Point) bool { return gd.At(p) == '#' }
+	hit, ok := Raycast(0.5, 2.5, 1, 0, 10, blocks)
+	if !ok || hit.P != (Point{4, 2}) || hit.Face != W || hit.X != 4 || hit.Y != 2.5 || hit.T != 3.5 {
+		t.Errorf("bad hit: %+v", hit)
+	}
+	if _, ok := Raycast(0.5, 2.5, 1, 0, 3, blocks); ok {
+		t.Errorf("hit beyond max distance")
+	}
+	hit, ok = Raycast(4.5, 0.25, 0, 1, 10, blocks)
+	if !ok || hit.P != (Point{4, 2}) || hit.Face != N || hit.Y != 2 {
+		t.Errorf("bad vertical hit: %+v", hit)
+	}
+	hit, ok = gd.RayFunc(0.5, 2.5, 1, 0, 10, func(p Point, c rune) bool { return c != '#' })
+	if !ok || hit.P != (Point{4, 2}) {
+		t.Errorf("bad grid hit: %+v", hit)
+	}
+	n := 0
+	_, ok = gd.RayFunc(-3.5, 0.5, 1, 0, math.Inf(1), func(p Point, c rune) bool {
+		n++
+		return true
+	})
+	if ok || n != 6 {
+		t.Errorf("bad ray through grid: %v %d", ok, n)
+	}
+	if _, ok := gd.RayFunc(-3.5, 0.5, -1, 0, math.Inf(1), func(Point, rune) bool { return false }); ok {
+		t.Errorf("hit for ray moving away from the grid")
+	}
+}
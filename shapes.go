@@ -0,0 +1,378 @@
+package grid
+
+import (
+	"iter"
+	"slices"
+)
+
+// Circle returns an iterator over the positions of the outline of the circle
+// of center c and radius r, computed with the midpoint circle algorithm. Each
+// position is yielded once, in no particular order. For r == 0, only c is
+// yielded, and for negative r, nothing.
+func Circle(c Point, r int) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		var buf [8]Point
+		x, y := r, 0
+		err := 1 - r
+		for x >= y {
+			pts := octants(buf[:0], x, y)
+			for _, p := range pts {
+				if !yield(c.Add(p)) {
+					return
+				}
+			}
+			y++
+			if err < 0 {
+				err += 2*y + 1
+			} else {
+				x--
+				err += 2*(y-x) + 1
+			}
+		}
+	}
+}
+
+// octants appends to buf the distinct points among the eight symmetric
+// variants of (x,y), and returns the updated slice.
+func octants(buf []Point, x, y int) []Point {
+	for _, p := range [...]Point{{x, y}, {-x, y}, {x, -y}, {-x, -y}, {y, x}, {-y, x}, {y, -x}, {-y, -x}} {
+		if !slices.Contains(buf, p) {
+			buf = append(buf, p)
+		}
+	}
+	return buf
+}
+
+// circleHalfWidths returns, for each line offset dy from 0 to r, the
+// half-width of the filled circle of radius r on that line, consistent with
+// the outline produced by Circle. It returns nil for negative r.
+func circleHalfWidths(r int) []int {
+	if r < 0 {
+		return nil
+	}
+	half := make([]int, r+1)
+	x, y := r, 0
+	err := 1 - r
+	for x >= y {
+		half[y] = max(half[y], x)
+		half[x] = max(half[x], y)
+		y++
+		if err < 0 {
+			err += 2*y + 1
+		} else {
+			x--
+			err += 2*(y-x) + 1
+		}
+	}
+	return half
+}
+
+// Disk returns an iterator over the positions of the filled circle of center
+// c and radius r, in row-major order. The disk contains the outline given by
+// Circle. For negative r, nothing is yielded.
+func Disk(c Point, r int) iter.Seq[Point] {
+	return spanPoints(func(yield func(int, int, int) bool) {
+		symmetricSpans(c, circleHalfWidths(r), yield)
+	})
+}
+
+// Ellipse returns an iterator over the positions of the outline of the
+// axis-aligned ellipse of center c and radii rx and ry, computed with the
+// midpoint ellipse algorithm. Each position is yielded once, in no
+// particular order. For negative radii, nothing is yielded.
+func Ellipse(c Point, rx, ry int) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		var buf [4]Point
+		midpointEllipse(rx, ry, func(x, y int) bool {
+			for _, p := range quadrants(buf[:0], x, y) {
+				if !yield(c.Add(p)) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+// quadrants appends to buf the distinct points among the four symmetric
+// variants of (x,y), and returns the updated slice.
+func quadrants(buf []Point, x, y int) []Point {
+	for _, p := range [...]Point{{x, y}, {-x, y}, {x, -y}, {-x, -y}} {
+		if !slices.Contains(buf, p) {
+			buf = append(buf, p)
+		}
+	}
+	return buf
+}
+
+// midpointEllipse calls fn for the positions (x,y) of the first quadrant of
+// the ellipse of radii rx and ry centered on the origin, stopping early if fn
+// returns false. Decision variables are scaled by 4 to avoid fractions.
+func midpointEllipse(rx, ry int, fn func(x, y int) bool) {
+	if rx < 0 || ry < 0 {
+		return
+	}
+	if ry == 0 {
+		for x := 0; x <= rx; x++ {
+			if !fn(x, 0) {
+				return
+			}
+		}
+		return
+	}
+	rx2, ry2 := rx*rx, ry*ry
+	x, y := 0, ry
+	px, py := 0, 2*rx2*y
+	// region 1: slope > -1
+	p := 4*ry2 - 4*rx2*ry + rx2
+	for px < py {
+		if !fn(x, y) {
+			return
+		}
+		x++
+		px += 2 * ry2
+		if p < 0 {
+			p += 4 * (ry2 + px)
+		} else {
+			y--
+			py -= 2 * rx2
+			p += 4 * (ry2 + px - py)
+		}
+	}
+	// region 2: slope <= -1
+	p = ry2*(2*x+1)*(2*x+1) + 4*rx2*(y-1)*(y-1) - 4*rx2*ry2
+	x0 := x // last x for y == 0
+	for y >= 0 {
+		if !fn(x, y) {
+			return
+		}
+		x0 = x
+		y--
+		py -= 2 * rx2
+		if p > 0 {
+			p += 4 * (rx2 - py)
+		} else {
+			x++
+			px += 2 * ry2
+			p += 4 * (rx2 - py + px)
+		}
+	}
+	// for very flat ellipses, region 2 may end before reaching rx
+	for x := x0 + 1; x <= rx; x++ {
+		if !fn(x, 0) {
+			return
+		}
+	}
+}
+
+// FilledEllipse returns an iterator over the positions of the filled
+// axis-aligned ellipse of center c and radii rx and ry, in row-major order.
+// The filled ellipse contains the outline given by Ellipse. For negative
+// radii, nothing is yielded.
+func FilledEllipse(c Point, rx, ry int) iter.Seq[Point] {
+	return spanPoints(func(yield func(int, int, int) bool) {
+		symmetricSpans(c, ellipseHalfWidths(rx, ry), yield)
+	})
+}
+
+// ellipseHalfWidths returns, for each line offset dy from 0 to ry, the
+// half-width of the filled ellipse of radii rx and ry on that line,
+// consistent with the outline produced by Ellipse. It returns nil for negative
+// radii.
+func ellipseHalfWidths(rx, ry int) []int {
+	if rx < 0 || ry < 0 {
+		return nil
+	}
+	half := make([]int, ry+1)
+	midpointEllipse(rx, ry, func(x, y int) bool {
+		half[y] = max(half[y], x)
+		return true
+	})
+	return half
+}
+
+// symmetricSpans calls yield, from top to bottom, for the horizontal spans of
+// a shape symmetric around center c, given the half-width of the shape for
+// each line offset from 0 to len(half)-1. Spans are given as a line y and a
+// range of columns from x0 (included) to x1 (excluded).
+func symmetricSpans(c Point, half []int, yield func(y, x0, x1 int) bool) {
+	r := len(half) - 1
+	for dy := -r; dy <= r; dy++ {
+		hw := half[abs(dy)]
+		if !yield(c.Y+dy, c.X-hw, c.X+hw+1) {
+			return
+		}
+	}
+}
+
+// spanPoints returns an iterator over the positions of the spans produced by
+// the given span sequence.
+func spanPoints(spans func(yield func(y, x0, x1 int) bool)) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		spans(func(y, x0, x1 int) bool {
+			for x := x0; x < x1; x++ {
+				if !yield(Point{x, y}) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+// FillRule represents a rule to determine which positions are inside a
+// polygon.
+type FillRule int
+
+// Available polygon fill rules.
+const (
+	// EvenOdd considers a position inside if a ray from it crosses the
+	// polygon's edges an odd number of times.
+	EvenOdd FillRule = iota
+	// NonZero considers a position inside if the winding number of the
+	// polygon around it is not zero.
+	NonZero
+)
+
+// Polygon returns an iterator over the positions of the filled polygon with
+// the given vertices, in row-major order, according to the given fill rule.
+// The polygon is closed automatically.
+//
+// Vertices are interpreted as grid line intersections, with position (x,y)
+// being the upper-left corner of cell (x,y), and a cell is considered inside
+// if its center is, so that for a range rg, the polygon with vertices rg.Min,
+// (rg.Max.X, rg.Min.Y), rg.Max and (rg.Min.X, rg.Max.Y) contains exactly the
+// positions of rg. Cell centers lying exactly on an edge are inside only if
+// it is a left edge.
+func Polygon(vs []Point, rule FillRule) iter.Seq[Point] {
+	return spanPoints(func(yield func(int, int, int) bool) {
+		polygonSpans(vs, rule, yield)
+	})
+}
+
+// crossing represents the intersection of an edge with a scanline: x is the
+// first column whose center is at the right of the intersection, and dir is
+// the edge's winding direction.
+type crossing struct {
+	x   int
+	dir int
+}
+
+func polygonSpans(vs []Point, rule FillRule, yield func(y, x0, x1 int) bool) {
+	if len(vs) < 3 {
+		return
+	}
+	ymin, ymax := vs[0].Y, vs[0].Y
+	for _, v := range vs[1:] {
+		ymin = min(ymin, v.Y)
+		ymax = max(ymax, v.Y)
+	}
+	var xs []crossing
+	for y := ymin; y < ymax; y++ {
+		xs = xs[:0]
+		for i, a := range vs {
+			b := vs[(i+1)%len(vs)]
+			dir := 1
+			if a.Y > b.Y {
+				a, b = b, a
+				dir = -1
+			}
+			if y < a.Y || y >= b.Y {
+				continue
+			}
+			// twice the intersection abscissa at scanline y+1/2 is
+			// num/den, and cell x is at the right of it if
+			// 2x+1 >= num/den.
+			den := b.Y - a.Y
+			num := 2*a.X*den + (2*y+1-2*a.Y)*(b.X-a.X)
+			xs = append(xs, crossing{x: ceilDiv(num-den, 2*den), dir: dir})
+		}
+		slices.SortFunc(xs, func(c1, c2 crossing) int { return c1.x - c2.x })
+		wn, x0 := 0, 0
+		for _, cr := range xs {
+			inside := wn != 0
+			if rule == NonZero {
+				wn += cr.dir
+			} else {
+				wn ^= 1
+			}
+			switch {
+			case !inside && wn != 0:
+				x0 = cr.x
+			case inside && wn == 0 && cr.x > x0:
+				if !yield(y, x0, cr.x) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ceilDiv returns the ceiling of a/b, for positive b.
+func ceilDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a > 0 {
+		q++
+	}
+	return q
+}
+
+// fillSpan sets to c the cells of line y between columns x0 (included) and
+// x1 (excluded) that are within the grid.
+func (gd Grid[T]) fillSpan(y, x0, x1 int, c T) {
+	gd.Slice(Range{Min: Point{x0, y}, Max: Point{x1, y + 1}}.Intersect(gd.Range())).Fill(c)
+}
+
+// DrawCircle sets to c the cells of the outline of the circle of center p and
+// radius r, as given by Circle. Positions out of the grid are ignored.
+func (gd Grid[T]) DrawCircle(p Point, r int, c T) {
+	for q := range Circle(p, r) {
+		gd.Set(q, c)
+	}
+}
+
+// FillCircle sets to c the cells of the filled circle of center p and radius
+// r, as given by Disk. Positions out of the grid are ignored.
+func (gd Grid[T]) FillCircle(p Point, r int, c T) {
+	symmetricSpans(p, circleHalfWidths(r), func(y, x0, x1 int) bool {
+		gd.fillSpan(y, x0, x1, c)
+		return true
+	})
+}
+
+// DrawEllipse sets to c the cells of the outline of the ellipse of center p
+// and radii rx and ry, as given by Ellipse. Positions out of the grid are
+// ignored.
+func (gd Grid[T]) DrawEllipse(p Point, rx, ry int, c T) {
+	for q := range Ellipse(p, rx, ry) {
+		gd.Set(q, c)
+	}
+}
+
+// FillEllipse sets to c the cells of the filled ellipse of center p and radii
+// rx and ry, as given by FilledEllipse. Positions out of the grid are
+// ignored.
+func (gd Grid[T]) FillEllipse(p Point, rx, ry int, c T) {
+	symmetricSpans(p, ellipseHalfWidths(rx, ry), func(y, x0, x1 int) bool {
+		gd.fillSpan(y, x0, x1, c)
+		return true
+	})
+}
+
+// FillPolygon sets to c the cells of the filled polygon with the given
+// vertices, as given by Polygon. Positions out of the grid are ignored.
+func (gd Grid[T]) FillPolygon(vs []Point, rule FillRule, c T) {
+	polygonSpans(vs, rule, func(y, x0, x1 int) bool {
+		gd.fillSpan(y, x0, x1, c)
+		return true
+	})
+}
+
+// DrawPolygon sets to c the cells of the closed polyline joining the given
+// positions, as given by Line. Unlike FillPolygon, vertices are cell
+// positions. Positions out of the grid are ignored.
+func (gd Grid[T]) DrawPolygon(vs []Point, c T) {
+	for i, p := range vs {
+		gd.DrawLine(p, vs[(i+1)%len(vs)], c)
+	}
+}
@@ -0,0 +1,151 @@
+package grid
+
+import "testing"
+
+func collectPoints(seq func(func(Point) bool)) map[Point]int {
+	m := map[Point]int{}
+	for p := range seq {
+		m[p]++
+	}
+	return m
+}
+
+func TestCircle(t *testing.T) {
+	c := Point{5, 5}
+	for r := 0; r < 12; r++ {
+		outline := collectPoints(Circle(c, r))
+		disk := collectPoints(Disk(c, r))
+		for p, n := range outline {
+			if n != 1 {
+				t.Errorf("radius %d: %v yielded %d times", r, p, n)
+			}
+			if d := p.DistSq(c); d < max(r-1, 0)*max(r-1, 0) || d > (r+1)*(r+1) {
+				t.Errorf("radius %d: bad outline position %v", r, p)
+			}
+			if disk[p] != 1 {
+				t.Errorf("radius %d: outline position %v not in disk", r, p)
+			}
+		}
+		for p, n := range disk {
+			if n != 1 {
+				t.Errorf("radius %d: %v yielded %d times in disk", r, p, n)
+			}
+			if p.DistSq(c) > (r+1)*(r+1) {
+				t.Errorf("radius %d: bad disk position %v", r, p)
+			}
+		}
+		if r > 0 && (disk[c.Shift(r, 0)] != 1 || disk[c.Shift(0, -r)] != 1) {
+			t.Errorf("radius %d: missing extreme positions", r)
+		}
+	}
+	if len(collectPoints(Circle(c, -1))) != 0 || len(collectPoints(Disk(c, -1))) != 0 {
+		t.Errorf("non empty negative radius circle")
+	}
+	if len(collectPoints(Disk(c, 1))) != 5 {
+		t.Errorf("bad radius 1 disk: %v", collectPoints(Disk(c, 1)))
+	}
+}
+
+func TestEllipse(t *testing.T) {
+	c := Point{0, 0}
+	for rx := 0; rx < 10; rx++ {
+		for ry := 0; ry < 10; ry++ {
+			outline := collectPoints(Ellipse(c, rx, ry))
+			filled := collectPoints(FilledEllipse(c, rx, ry))
+			for p, n := range outline {
+				if n != 1 {
+					t.Errorf("radii %d,%d: %v yielded %d times", rx, ry, p, n)
+				}
+				if filled[p] != 1 {
+					t.Errorf("radii %d,%d: outline position %v not filled", rx, ry, p)
+				}
+			}
+			for _, p := range []Point{{rx, 0}, {-rx, 0}, {0, ry}, {0, -ry}} {
+				if outline[p] != 1 {
+					t.Errorf("radii %d,%d: missing extreme position %v", rx, ry, p)
+				}
+			}
+			for p := range filled {
+				if abs(p.X) > rx || abs(p.Y) > ry {
+					t.Errorf("radii %d,%d: bad filled position %v", rx, ry, p)
+				}
+			}
+		}
+	}
+	if len(collectPoints(Ellipse(c, 5, 5))) != len(collectPoints(Circle(c, 5))) {
+		t.Errorf("bad circular ellipse")
+	}
+}
+
+func TestPolygon(t *testing.T) {
+	rg := NewRange(2, 3, 7, 9)
+	square := []Point{rg.Min, {rg.Max.X, rg.Min.Y}, rg.Max, {rg.Min.X, rg.Max.Y}}
+	for _, rule := range []FillRule{EvenOdd, NonZero} {
+		ps := collectPoints(Polygon(square, rule))
+		if len(ps) != 30 {
+			t.Errorf("bad number of positions: %d", len(ps))
+		}
+		for p := range ps {
+			if !p.In(rg) {
+				t.Errorf("bad position %v", p)
+			}
+		}
+	}
+	triangle := []Point{{0, 0}, {10, 0}, {0, 10}}
+	if n := len(collectPoints(Polygon(triangle, EvenOdd))); n != 45 {
+		t.Errorf("bad triangle area: %d", n)
+	}
+	// a self-overlapping polygon going twice around the square
+	twice := append(append([]Point{}, square...), square...)
+	if n := len(collectPoints(Polygon(twice, EvenOdd))); n != 0 {
+		t.Errorf("bad even-odd double winding: %d", n)
+	}
+	if n := len(collectPoints(Polygon(twice, NonZero))); n != 30 {
+		t.Errorf("bad non-zero double winding: %d", n)
+	}
+	if len(collectPoints(Polygon(square[:2], EvenOdd))) != 0 {
+		t.Errorf("non empty degenerate polygon")
+	}
+}
+
+func TestGridShapes(t *testing.T) {
+	gd := NewGrid[int](20, 20)
+	c := Point{3, 4}
+	gd.FillCircle(c, 6, 1)
+	disk := collectPoints(Disk(c, 6))
+	gd.Iter(func(p Point, v int) {
+		if (disk[p] == 1) != (v == 1) {
+			t.Errorf("bad FillCircle at %v: %d", p, v)
+		}
+	})
+	gd.Fill(0)
+	gd.DrawCircle(c, 6, 1)
+	outline := collectPoints(Circle(c, 6))
+	gd.Iter(func(p Point, v int) {
+		if (outline[p] == 1) != (v == 1) {
+			t.Errorf("bad DrawCircle at %v: %d", p, v)
+		}
+	})
+	gd.Fill(0)
+	gd.FillEllipse(Point{15, 15}, 8, 3, 1)
+	filled := collectPoints(FilledEllipse(Point{15, 15}, 8, 3))
+	gd.Iter(func(p Point, v int) {
+		if (filled[p] == 1) != (v == 1) {
+			t.Errorf("bad FillEllipse at %v: %d", p, v)
+		}
+	})
+	gd.Fill(0)
+	triangle := []Point{{-5, -5}, {30, 2}, {4, 25}}
+	gd.FillPolygon(triangle, NonZero, 1)
+	poly := collectPoints(Polygon(triangle, NonZero))
+	gd.Iter(func(p Point, v int) {
+		if (poly[p] == 1) != (v == 1) {
+			t.Errorf("bad FillPolygon at %v: %d", p, v)
+		}
+	})
+	gd.Fill(0)
+	gd.DrawPolygon([]Point{{1, 1}, {5, 1}, {5, 5}}, 1)
+	if gd.At(Point{3, 1}) != 1 || gd.At(Point{5, 3}) != 1 || gd.At(Point{3, 3}) != 1 || gd.At(Point{2, 3}) != 0 {
+		t.Errorf("bad DrawPolygon")
+	}
+}
@@ -0,0 +1,210 @@
+package grid
+
+import (
+	"fmt"
+	"slices"
+)
+
+// row returns the cells of line y of the grid.
+func (gd Grid[T]) row(y int) []T {
+	w := gd.ug.Width
+	i := (gd.rg.Min.Y+y)*w + gd.rg.Min.X
+	return gd.ug.Cells[i : i+gd.rg.Max.X-gd.rg.Min.X]
+}
+
+// Transpose returns a new grid with lines and columns swapped: the cell at
+// position (x,y) in gd is at position (y,x) in the result.
+func (gd Grid[T]) Transpose() Grid[T] {
+	max := gd.Size()
+	res := NewGrid[T](max.Y, max.X)
+	if max.X == 0 || max.Y == 0 {
+		return res
+	}
+	cells := res.ug.Cells
+	for y := 0; y < max.Y; y++ {
+		for x, c := range gd.row(y) {
+			cells[x*max.Y+y] = c
+		}
+	}
+	return res
+}
+
+// Rotate90 returns a new grid rotated clockwise by 90 degrees: the cell at
+// position (x,y) in gd is at position (h-1-y,x) in the result, where h is
+// the height of gd. The rotation is clockwise as displayed, with the Y axis
+// pointing down.
+func (gd Grid[T]) Rotate90() Grid[T] {
+	max := gd.Size()
+	res := NewGrid[T](max.Y, max.X)
+	if max.X == 0 || max.Y == 0 {
+		return res
+	}
+	cells := res.ug.Cells
+	for y := 0; y < max.Y; y++ {
+		for x, c := range gd.row(y) {
+			cells[x*max.Y+max.Y-1-y] = c
+		}
+	}
+	return res
+}
+
+// Rotate180 returns a new grid rotated by 180 degrees: the cell at position
+// (x,y) in gd is at position (w-1-x,h-1-y) in the result, where (w,h) is
+// the size of gd.
+func (gd Grid[T]) Rotate180() Grid[T] {
+	max := gd.Size()
+	res := NewGrid[T](max.X, max.Y)
+	if max.X == 0 || max.Y == 0 {
+		return res
+	}
+	for y := 0; y < max.Y; y++ {
+		dst := res.row(max.Y - 1 - y)
+		for x, c := range gd.row(y) {
+			dst[max.X-1-x] = c
+		}
+	}
+	return res
+}
+
+// Rotate270 returns a new grid rotated clockwise by 270 degrees, that is
+// counterclockwise by 90 degrees: the cell at position (x,y) in gd is at
+// position (y,w-1-x) in the result, where w is the width of gd. As for
+// Rotate90, the direction is as displayed, with the Y axis pointing down.
+func (gd Grid[T]) Rotate270() Grid[T] {
+	max := gd.Size()
+	res := NewGrid[T](max.Y, max.X)
+	if max.X == 0 || max.Y == 0 {
+		return res
+	}
+	cells := res.ug.Cells
+	for y := 0; y < max.Y; y++ {
+		for x, c := range gd.row(y) {
+			cells[(max.X-1-x)*max.Y+y] = c
+		}
+	}
+	return res
+}
+
+// FlipH returns a new grid mirrored horizontally, that is with columns in
+// reverse order: the cell at position (x,y) in gd is at position (w-1-x,y)
+// in the result, where w is the width of gd.
+func (gd Grid[T]) FlipH() Grid[T] {
+	max := gd.Size()
+	res := NewGrid[T](max.X, max.Y)
+	if max.X == 0 || max.Y == 0 {
+		return res
+	}
+	for y := 0; y < max.Y; y++ {
+		dst := res.row(y)
+		for x, c := range gd.row(y) {
+			dst[max.X-1-x] = c
+		}
+	}
+	return res
+}
+
+// FlipV returns a new grid mirrored vertically, that is with lines in
+// reverse order: the cell at position (x,y) in gd is at position (x,h-1-y)
+// in the result, where h is the height of gd.
+func (gd Grid[T]) FlipV() Grid[T] {
+	max := gd.Size()
+	res := NewGrid[T](max.X, max.Y)
+	if max.X == 0 || max.Y == 0 {
+		return res
+	}
+	for y := 0; y < max.Y; y++ {
+		copy(res.row(max.Y-1-y), gd.row(y))
+	}
+	return res
+}
+
+// FlipHInPlace mirrors the grid horizontally in place, as FlipH does.
+func (gd Grid[T]) FlipHInPlace() {
+	max := gd.Size()
+	if max.X == 0 {
+		return
+	}
+	for y := 0; y < max.Y; y++ {
+		slices.Reverse(gd.row(y))
+	}
+}
+
+// FlipVInPlace mirrors the grid vertically in place, as FlipV does.
+func (gd Grid[T]) FlipVInPlace() {
+	max := gd.Size()
+	if max.X == 0 {
+		return
+	}
+	for y := 0; y < max.Y/2; y++ {
+		swapCells(gd.row(y), gd.row(max.Y-1-y))
+	}
+}
+
+// Rotate180InPlace rotates the grid by 180 degrees in place, as Rotate180
+// does.
+func (gd Grid[T]) Rotate180InPlace() {
+	max := gd.Size()
+	if max.X == 0 {
+		return
+	}
+	for y := 0; y < max.Y/2; y++ {
+		r1, r2 := gd.row(y), gd.row(max.Y-1-y)
+		for x := range r1 {
+			r1[x], r2[max.X-1-x] = r2[max.X-1-x], r1[x]
+		}
+	}
+	if max.Y%2 == 1 {
+		slices.Reverse(gd.row(max.Y / 2))
+	}
+}
+
+// TransposeInPlace transposes the grid in place, as Transpose does. The grid
+// should be square: it panics otherwise.
+func (gd Grid[T]) TransposeInPlace() {
+	max := gd.Size()
+	if max.X != max.Y {
+		panic(fmt.Sprintf("TransposeInPlace: non-square grid of size %v", max))
+	}
+	if max.X == 0 {
+		return
+	}
+	w := gd.ug.Width
+	cells := gd.ug.Cells
+	base := gd.rg.Min.Y*w + gd.rg.Min.X
+	for y := 0; y < max.Y; y++ {
+		for x := y + 1; x < max.X; x++ {
+			i, j := base+y*w+x, base+x*w+y
+			cells[i], cells[j] = cells[j], cells[i]
+		}
+	}
+}
+
+// Rotate90InPlace rotates the grid clockwise by 90 degrees in place, as
+// Rotate90 does. The grid should be square: it panics otherwise.
+func (gd Grid[T]) Rotate90InPlace() {
+	max := gd.Size()
+	if max.X != max.Y {
+		panic(fmt.Sprintf("Rotate90InPlace: non-square grid of size %v", max))
+	}
+	gd.TransposeInPlace()
+	gd.FlipHInPlace()
+}
+
+// Rotate270InPlace rotates the grid clockwise by 270 degrees in place, as
+// Rotate270 does. The grid should be square: it panics otherwise.
+func (gd Grid[T]) Rotate270InPlace() {
+	max := gd.Size()
+	if max.X != max.Y {
+		panic(fmt.Sprintf("Rotate270InPlace: non-square grid of size %v", max))
+	}
+	gd.TransposeInPlace()
+	gd.FlipVInPlace()
+}
+
+// swapCells swaps the elements of s1 and s2, which should have the same
+// length.
+func swapCells[T any](s1, s2 []T) {
+	for i := range s1 {
+		s1[i], s2[i] = s2[i], s1[i]
+	}
+}
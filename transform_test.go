@@ -0,0 +1,71 @@
+package grid
+
+import "testing"
+
+func TestTransforms(t *testing.T) {
+	big := NewGrid[int](9, 8)
+	big.Map(func(p Point, _ int) int { return 100*p.Y + p.X })
+	gd := big.Slice(NewRange(2, 1, 7, 4)) // 5x3
+	w, h := 5, 3
+	for _, tc := range []struct {
+		name string
+		res  Grid[int]
+		size Point
+		pos  func(p Point) Point
+	}{
+		{"Transpose", gd.Transpose(), Point{h, w}, func(p Point) Point { return Point{p.Y, p.X} }},
+		{"Rotate90", gd.Rotate90(), Point{h, w}, func(p Point) Point { return Point{h - 1 - p.Y, p.X} }},
+		{"Rotate180", gd.Rotate180(), Point{w, h}, func(p Point) Point { return Point{w - 1 - p.X, h - 1 - p.Y} }},
+		{"Rotate270", gd.Rotate270(), Point{h, w}, func(p Point) Point { return Point{p.Y, w - 1 - p.X} }},
+		{"FlipH", gd.FlipH(), Point{w, h}, func(p Point) Point { return Point{w - 1 - p.X, p.Y} }},
+		{"FlipV", gd.FlipV(), Point{w, h}, func(p Point) Point { return Point{p.X, h - 1 - p.Y} }},
+	} {
+		if tc.res.Size() != tc.size {
+			t.Errorf("%s: bad size %v", tc.name, tc.res.Size())
+			continue
+		}
+		for p, c := range gd.All() {
+			if q := tc.pos(p); tc.res.At(q) != c {
+				t.Errorf("%s: bad cell at %v: %d vs %d", tc.name, q, tc.res.At(q), c)
+			}
+		}
+	}
+	if (Grid[int]{}).Rotate90().Size() != (Point{}) {
+		t.Errorf("bad rotation of empty grid")
+	}
+}
+
+func TestTransformsInPlace(t *testing.T) {
+	big := NewGrid[int](9, 8)
+	big.Map(func(p Point, _ int) int { return 100*p.Y + p.X })
+	sq := big.Slice(NewRange(1, 2, 5, 6))
+	rect := big.Slice(NewRange(4, 1, 9, 4))
+	for _, tc := range []struct {
+		name    string
+		gd      Grid[int]
+		fn      func(Grid[int])
+		newGrid func(Grid[int]) Grid[int]
+	}{
+		{"Transpose", sq, Grid[int].TransposeInPlace, Grid[int].Transpose},
+		{"Rotate90", sq, Grid[int].Rotate90InPlace, Grid[int].Rotate90},
+		{"Rotate270", sq, Grid[int].Rotate270InPlace, Grid[int].Rotate270},
+		{"Rotate180", rect, Grid[int].Rotate180InPlace, Grid[int].Rotate180},
+		{"FlipH", rect, Grid[int].FlipHInPlace, Grid[int].FlipH},
+		{"FlipV", rect, Grid[int].FlipVInPlace, Grid[int].FlipV},
+	} {
+		want := tc.newGrid(tc.gd)
+		before := NewGrid[int](9, 8)
+		before.Copy(big)
+		tc.fn(tc.gd)
+		if !equalGrids(tc.gd, want) {
+			t.Errorf("%s: in-place result differs", tc.name)
+		}
+		for p, c := range big.All() {
+			if !p.In(tc.gd.Bounds()) && before.At(p) != c {
+				t.Errorf("%s: cell out of grid slice modified at %v", tc.name, p)
+			}
+		}
+	}
+	testPanic(t, func() { rect.Rotate90InPlace() }, "rotating a non-square grid in place")
+	testPanic(t, func() { rect.TransposeInPlace() }, "transposing a non-square grid in place")
+}
@@ -0,0 +1,307 @@
+package grid
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Orientation represents one of the eight symmetries of a rectangle: the
+// four rotations, possibly combined with a flip. Rotations are clockwise as
+// displayed, with the Y axis pointing down.
+type Orientation uint8
+
+// The eight orientations. Each one corresponds to the grid method of similar
+// name returning a new transformed grid.
+const (
+	OrientIdentity      Orientation = iota // no transformation
+	OrientRotate90                         // as Rotate90
+	OrientRotate180                        // as Rotate180
+	OrientRotate270                        // as Rotate270
+	OrientFlipH                            // as FlipH
+	OrientFlipV                            // as FlipV
+	OrientTranspose                        // as Transpose
+	OrientAntiTranspose                    // transpose along the other diagonal
+)
+
+var orientationNames = [...]string{
+	"OrientIdentity", "OrientRotate90", "OrientRotate180", "OrientRotate270",
+	"OrientFlipH", "OrientFlipV", "OrientTranspose", "OrientAntiTranspose",
+}
+
+// String returns the name of the orientation constant.
+func (o Orientation) String() string {
+	if int(o) >= len(orientationNames) {
+		return fmt.Sprintf("Orientation(%d)", o)
+	}
+	return orientationNames[o]
+}
+
+// Swapped reports whether the orientation swaps the width and height.
+func (o Orientation) Swapped() bool {
+	switch o {
+	case OrientRotate90, OrientRotate270, OrientTranspose, OrientAntiTranspose:
+		return true
+	}
+	return false
+}
+
+// View represents a lazily transformed view of a grid, sharing memory with
+// it: accessing position p in the view accesses a corresponding position in
+// the grid, without any copy. Views are created with the Grid.Orient or
+// Grid.Step methods, and may be further transformed, strided or sliced.
+//
+// In the underlying whole grid, the view's position p is the position
+// o + p.X*ax + p.Y*ay, for an origin o and two axis steps ax and ay.
+type View[T any] struct {
+	ug   *grid[T] // underlying whole grid
+	o    Point    // position of (0,0) in the underlying grid
+	ax   Point    // underlying step for a unit step along X
+	ay   Point    // underlying step for a unit step along Y
+	size Point    // view's size
+}
+
+// Orient returns a view of the grid with the given orientation, sharing
+// memory with the grid. For example, gd.Orient(OrientRotate90).At(p) is the
+// same as gd.Rotate90().At(p), but without any allocation.
+func (gd Grid[T]) Orient(o Orientation) View[T] {
+	v := View[T]{ug: gd.ug, o: gd.rg.Min, ax: Point{1, 0}, ay: Point{0, 1}, size: gd.rg.Size()}
+	return v.Orient(o)
+}
+
+// Orient returns a view of the view with the given orientation, applied
+// after the view's own transformation.
+func (v View[T]) Orient(o Orientation) View[T] {
+	w, h := v.size.X, v.size.Y
+	var org, ax, ay Point // in view coordinates
+	switch o {
+	case OrientRotate90:
+		org, ax, ay = Point{0, h - 1}, Point{0, -1}, Point{1, 0}
+	case OrientRotate180:
+		org, ax, ay = Point{w - 1, h - 1}, Point{-1, 0}, Point{0, -1}
+	case OrientRotate270:
+		org, ax, ay = Point{w - 1, 0}, Point{0, 1}, Point{-1, 0}
+	case OrientFlipH:
+		org, ax, ay = Point{w - 1, 0}, Point{-1, 0}, Point{0, 1}
+	case OrientFlipV:
+		org, ax, ay = Point{0, h - 1}, Point{1, 0}, Point{0, -1}
+	case OrientTranspose:
+		org, ax, ay = Point{0, 0}, Point{0, 1}, Point{1, 0}
+	case OrientAntiTranspose:
+		org, ax, ay = Point{w - 1, h - 1}, Point{0, -1}, Point{-1, 0}
+	default:
+		return v
+	}
+	if w == 0 || h == 0 {
+		org = Point{}
+	}
+	res := View[T]{
+		ug: v.ug,
+		o:  v.o.Add(v.ax.Mul(org.X)).Add(v.ay.Mul(org.Y)),
+		ax: v.ax.Mul(ax.X).Add(v.ay.Mul(ax.Y)),
+		ay: v.ax.Mul(ay.X).Add(v.ay.Mul(ay.Y)),
+	}
+	res.size = v.size
+	if o.Swapped() {
+		res.size = Point{h, w}
+	}
+	return res
+}
+
+// Step returns a strided view of the grid selecting every sx-th column and
+// every sy-th line, starting from position (0,0), and sharing memory with the
+// grid. Position p in the view corresponds to position (p.X*sx, p.Y*sy) in
+// the grid. For example, gd.Slice(gd.Range().Shift(1, 0, 0, 0)).Step(2, 1)
+// selects odd columns. The steps should be positive: it panics otherwise.
+func (gd Grid[T]) Step(sx, sy int) View[T] {
+	return gd.View().Step(sx, sy)
+}
+
+// Step returns a strided view of the view selecting every sx-th column and
+// every sy-th line, starting from position (0,0). The steps should be
+// positive: it panics otherwise.
+func (v View[T]) Step(sx, sy int) View[T] {
+	if sx <= 0 || sy <= 0 {
+		panic(fmt.Sprintf("non-positive steps: Step(%d,%d)", sx, sy))
+	}
+	v.ax = v.ax.Mul(sx)
+	v.ay = v.ay.Mul(sy)
+	v.size = Point{ceilDiv(v.size.X, sx), ceilDiv(v.size.Y, sy)}
+	return v
+}
+
+// Size returns the view's (width, height) in cells.
+func (v View[T]) Size() Point {
+	return v.size
+}
+
+// Range returns the range with Min set to (0,0) and Max set to v.Size().
+func (v View[T]) Range() Range {
+	return Range{Max: v.size}
+}
+
+// Contains returns true if the given relative position is within the view.
+func (v View[T]) Contains(p Point) bool {
+	return p.In(v.Range())
+}
+
+// idx returns the index in the underlying cells of the view's position p.
+func (v View[T]) idx(p Point) int {
+	q := v.o.Add(v.ax.Mul(p.X)).Add(v.ay.Mul(p.Y))
+	return q.Y*v.ug.Width + q.X
+}
+
+// steps returns the underlying index steps for a unit step along X and Y.
+func (v View[T]) steps() (dx, dy int) {
+	w := v.ug.Width
+	return v.ax.Y*w + v.ax.X, v.ay.Y*w + v.ay.X
+}
+
+// At returns the cell content at a given position. If the position is out of
+// range, it returns the zero value.
+func (v View[T]) At(p Point) T {
+	if !v.Contains(p) {
+		var zero T
+		return zero
+	}
+	return v.ug.Cells[v.idx(p)]
+}
+
+// Set draws a cell at a given position in the view. If the position is out
+// of range, the function does nothing.
+func (v View[T]) Set(p Point, c T) {
+	if !v.Contains(p) {
+		return
+	}
+	v.ug.Cells[v.idx(p)] = c
+}
+
+// Slice returns a rectangular slice of the view given by a range relative to
+// the view, intersected with the view's range. The result shares memory with
+// the view.
+func (v View[T]) Slice(rg Range) View[T] {
+	rg = rg.Intersect(v.Range())
+	if rg.Empty() {
+		return View[T]{ug: v.ug, ax: v.ax, ay: v.ay}
+	}
+	v.o = v.o.Add(v.ax.Mul(rg.Min.X)).Add(v.ay.Mul(rg.Min.Y))
+	v.size = rg.Size()
+	return v
+}
+
+// Iter iterates a function on all the view positions and cells, in
+// row-major order of the view.
+func (v View[T]) Iter(fn func(Point, T)) {
+	for p, c := range v.All() {
+		fn(p, c)
+	}
+}
+
+// All returns an iterator over all the view positions and cells, in
+// row-major order of the view.
+func (v View[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		if v.ug == nil || v.size.X <= 0 {
+			return
+		}
+		dx, dy := v.steps()
+		cells := v.ug.Cells
+		for y, yi := 0, v.idx(Point{}); y < v.size.Y; y, yi = y+1, yi+dy {
+			for x, xi := 0, yi; x < v.size.X; x, xi = x+1, xi+dx {
+				if !yield(Point{x, y}, cells[xi]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Map updates the view's content using the given mapping function, in
+// row-major order of the view.
+func (v View[T]) Map(fn func(Point, T) T) {
+	if v.ug == nil || v.size.X <= 0 {
+		return
+	}
+	dx, dy := v.steps()
+	cells := v.ug.Cells
+	for y, yi := 0, v.idx(Point{}); y < v.size.Y; y, yi = y+1, yi+dy {
+		for x, xi := 0, yi; x < v.size.X; x, xi = x+1, xi+dx {
+			cells[xi] = fn(Point{x, y}, cells[xi])
+		}
+	}
+}
+
+// Fill sets the given value to all the view's cells.
+func (v View[T]) Fill(c T) {
+	if gd, ok := v.grid(); ok {
+		gd.Fill(c)
+		return
+	}
+	v.Map(func(Point, T) T { return c })
+}
+
+// grid returns the grid slice corresponding to the view, if the view is not
+// transformed.
+func (v View[T]) grid() (Grid[T], bool) {
+	if v.ax != (Point{1, 0}) || v.ay != (Point{0, 1}) {
+		return Grid[T]{}, false
+	}
+	return Grid[T]{ug: v.ug, rg: Range{v.o, v.o.Add(v.size)}}, true
+}
+
+// bounds returns the smallest range of the underlying grid containing the
+// view's positions.
+func (v View[T]) bounds() Range {
+	if v.size.X <= 0 || v.size.Y <= 0 {
+		return Range{}
+	}
+	p, q := v.o, v.o.Add(v.ax.Mul(v.size.X-1)).Add(v.ay.Mul(v.size.Y-1))
+	return Range{p.Min(q), p.Max(q).Shift(1, 1)}
+}
+
+// Copy copies elements from a source view src into the destination view v,
+// and returns the copied size, which is the minimum of both views for each
+// dimension. The result is independent of whether the two views' referenced
+// memory overlaps or not: if it does, a temporary buffer may be allocated,
+// unless neither view is transformed.
+func (v View[T]) Copy(src View[T]) Point {
+	max := v.Range().Intersect(src.Range()).Size()
+	if v.ug == nil || src.ug == nil || max.X == 0 || max.Y == 0 {
+		return Point{}
+	}
+	v, src = v.Slice(Range{Max: max}), src.Slice(Range{Max: max})
+	if gd, ok := v.grid(); ok {
+		if gsrc, ok := src.grid(); ok {
+			return gd.Copy(gsrc)
+		}
+	}
+	if v.ug == src.ug && v.bounds().Overlaps(src.bounds()) {
+		tmp := make([]T, 0, max.X*max.Y)
+		for _, c := range src.All() {
+			tmp = append(tmp, c)
+		}
+		v.Map(func(p Point, _ T) T { return tmp[p.Y*max.X+p.X] })
+		return max
+	}
+	dx, dy := v.steps()
+	sdx, sdy := src.steps()
+	cells, scells := v.ug.Cells, src.ug.Cells
+	for y, yi, syi := 0, v.idx(Point{}), src.idx(Point{}); y < max.Y; y, yi, syi = y+1, yi+dy, syi+sdy {
+		switch {
+		case dx == 1 && sdx == 1:
+			copy(cells[yi:yi+max.X], scells[syi:syi+max.X])
+		case dx == -1 && sdx == -1:
+			copy(cells[yi-max.X+1:yi+1], scells[syi-max.X+1:syi+1])
+		default:
+			for x, xi, sxi := 0, yi, syi; x < max.X; x, xi, sxi = x+1, xi+dx, sxi+sdx {
+				cells[xi] = scells[sxi]
+			}
+		}
+	}
+	return max
+}
+
+// View returns an untransformed view of the grid, sharing memory with it. It
+// may be convenient to copy a grid into a view or vice versa.
+func (gd Grid[T]) View() View[T] {
+	return gd.Orient(OrientIdentity)
+}
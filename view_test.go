@@ -0,0 +1,187 @@
+package grid
+
+import "testing"
+
+func equalView[T comparable](v View[T], gd Grid[T]) bool {
+	if v.Size() != gd.Size() {
+		return false
+	}
+	for p, c := range gd.All() {
+		if v.At(p) != c {
+			return false
+		}
+	}
+	return true
+}
+
+func TestViewOrient(t *testing.T) {
+	big := NewGrid[int](9, 8)
+	big.Map(func(p Point, _ int) int { return 100*p.Y + p.X })
+	gd := big.Slice(NewRange(2, 1, 7, 4))
+	for _, tc := range []struct {
+		o    Orientation
+		want Grid[int]
+	}{
+		{OrientIdentity, gd},
+		{OrientRotate90, gd.Rotate90()},
+		{OrientRotate180, gd.Rotate180()},
+		{OrientRotate270, gd.Rotate270()},
+		{OrientFlipH, gd.FlipH()},
+		{OrientFlipV, gd.FlipV()},
+		{OrientTranspose, gd.Transpose()},
+		{OrientAntiTranspose, gd.Rotate90().FlipV()},
+	} {
+		v := gd.Orient(tc.o)
+		if !equalView(v, tc.want) {
+			t.Errorf("bad view for %v", tc.o)
+		}
+		n := 0
+		v.Iter(func(p Point, c int) {
+			if tc.want.At(p) != c {
+				t.Errorf("bad iteration for %v at %v", tc.o, p)
+			}
+			n++
+		})
+		if n != 15 {
+			t.Errorf("bad iteration count for %v: %d", tc.o, n)
+		}
+		if tc.o.Swapped() != (v.Size() != gd.Size()) {
+			t.Errorf("bad swapped for %v", tc.o)
+		}
+	}
+	// composition
+	v := gd.Orient(OrientRotate90).Orient(OrientRotate90)
+	if !equalView(v, gd.Rotate180()) {
+		t.Errorf("bad composed rotation")
+	}
+	v = gd.Orient(OrientFlipH).Orient(OrientRotate90)
+	if !equalView(v, gd.FlipH().Rotate90()) {
+		t.Errorf("bad composed flip and rotation")
+	}
+	if OrientRotate90.String() != "OrientRotate90" || Orientation(12).String() != "Orientation(12)" {
+		t.Errorf("bad orientation string")
+	}
+}
+
+func TestViewAccess(t *testing.T) {
+	gd := NewGrid[int](4, 3)
+	v := gd.Orient(OrientRotate90)
+	v.Set(Point{0, 0}, 1)
+	if gd.At(Point{0, 2}) != 1 {
+		t.Errorf("bad view set")
+	}
+	v.Set(Point{3, 0}, 5)
+	if gd.At(Point{3, 2}) != 0 || v.At(Point{3, 0}) != 0 {
+		t.Errorf("out of range set")
+	}
+	sub := v.Slice(NewRange(1, 1, 3, 4))
+	if sub.Size() != (Point{2, 3}) {
+		t.Errorf("bad slice size: %v", sub.Size())
+	}
+	sub.Fill(7)
+	// sub covers view columns 1-2 and lines 1-3, that is grid lines 1-0
+	// and columns 1-3
+	for p, c := range gd.All() {
+		want := 0
+		if p.Y <= 1 && p.X >= 1 {
+			want = 7
+		}
+		if p == (Point{0, 2}) {
+			want = 1
+		}
+		if c != want {
+			t.Errorf("bad cell after fill at %v: %d", p, c)
+		}
+	}
+	sub.Map(func(p Point, c int) int { return c + p.X })
+	if gd.At(Point{1, 0}) != 8 || gd.At(Point{1, 1}) != 7 {
+		t.Errorf("bad view map: %d %d", gd.At(Point{1, 0}), gd.At(Point{1, 1}))
+	}
+	if v.Slice(NewRange(5, 5, 7, 7)).Size() != (Point{}) {
+		t.Errorf("bad empty slice")
+	}
+}
+
+func TestViewCopy(t *testing.T) {
+	gd := NewGrid[int](5, 5)
+	gd.Map(func(p Point, _ int) int { return 10*p.Y + p.X })
+	want := gd.Rotate90()
+	// overlapping copy
+	if max := gd.View().Copy(gd.Orient(OrientRotate90)); max != (Point{5, 5}) {
+		t.Errorf("bad copy size: %v", max)
+	}
+	if !equalGrids(gd, want) {
+		t.Errorf("bad overlapping rotated copy")
+	}
+	// non overlapping copies, with row fast paths
+	src := NewGrid[int](6, 4)
+	src.Map(func(p Point, _ int) int { return 10*p.Y + p.X })
+	for _, o := range []Orientation{OrientIdentity, OrientFlipH, OrientFlipV, OrientTranspose} {
+		dst := NewGrid[int](8, 8)
+		dv := dst.Slice(NewRange(1, 1, 7, 5)).Orient(o)
+		max := dv.Copy(src.Orient(o))
+		if max != src.Orient(o).Size() {
+			t.Errorf("bad copy size for %v: %v", o, max)
+		}
+		if !equalView(dst.Slice(NewRange(1, 1, 7, 5)).View(), src) {
+			t.Errorf("bad copy for %v", o)
+		}
+	}
+	// overlapping untransformed views
+	gd.Map(func(p Point, _ int) int { return p.X })
+	gd.Slice(NewRange(1, 0, 5, 5)).View().Copy(gd.View())
+	if gd.At(Point{4, 2}) != 3 || gd.At(Point{0, 2}) != 0 {
+		t.Errorf("bad overlapping copy")
+	}
+}
+
+func TestViewStep(t *testing.T) {
+	gd := NewGrid[int](7, 5)
+	gd.Map(func(p Point, _ int) int { return 10*p.Y + p.X })
+	v := gd.Step(2, 3)
+	if v.Size() != (Point{4, 2}) {
+		t.Fatalf("bad strided size: %v", v.Size())
+	}
+	v.Iter(func(p Point, c int) {
+		if c != 30*p.Y+2*p.X {
+			t.Errorf("bad strided cell at %v: %d", p, c)
+		}
+	})
+	if v.At(Point{4, 0}) != 0 || v.At(Point{3, 1}) != 36 {
+		t.Errorf("bad strided access")
+	}
+	// checkerboard
+	gd.Fill(0)
+	gd.Step(2, 2).Fill(1)
+	gd.Slice(gd.Range().Shift(1, 1, 0, 0)).Step(2, 2).Fill(1)
+	for p, c := range gd.All() {
+		if want := 1 - (p.X+p.Y)%2; c != want {
+			t.Errorf("bad checkerboard at %v: %d", p, c)
+		}
+	}
+	odd := gd.Slice(gd.Range().Shift(1, 0, 0, 0)).Step(2, 1)
+	odd.Set(Point{1, 2}, 5)
+	if gd.At(Point{3, 2}) != 5 || odd.Size() != (Point{3, 5}) {
+		t.Errorf("bad strided set")
+	}
+	// strided and oriented
+	rv := gd.Step(2, 1).Orient(OrientRotate90)
+	if rv.Size() != (Point{5, 4}) || rv.At(Point{0, 1}) != gd.At(Point{2, 4}) {
+		t.Errorf("bad strided rotation")
+	}
+	// copy from and into strided views
+	dst := NewGrid[int](4, 3)
+	if max := dst.View().Copy(gd.Step(2, 2)); max != (Point{4, 3}) {
+		t.Errorf("bad strided copy size: %v", max)
+	}
+	for p, c := range dst.All() {
+		if c != gd.At(p.Mul(2)) {
+			t.Errorf("bad strided copy at %v", p)
+		}
+	}
+	gd.Step(2, 2).Copy(gd.Slice(gd.Range().Shift(1, 0, 0, 0)).Step(2, 2))
+	if gd.At(Point{0, 0}) != 0 || gd.At(Point{2, 2}) != 5 {
+		t.Errorf("bad overlapping strided copy")
+	}
+	testPanic(t, func() { gd.Step(0, 1) }, "zero step")
+}
@@ -0,0 +1,142 @@
+package grid
+
+// AtWrap returns the cell at a given position, wrapping around the grid's
+// edges if the position is out of range. It returns the zero value if the
+// grid is empty.
+func (gd Grid[T]) AtWrap(p Point) T {
+	if gd.empty() {
+		var zero T
+		return zero
+	}
+	q := p.Mod(gd.Range()).Add(gd.rg.Min)
+	return gd.ug.Cells[q.Y*gd.ug.Width+q.X]
+}
+
+// SetWrap draws a cell at a given position in the grid, wrapping around the
+// grid's edges if the position is out of range. If the grid is empty, the
+// function does nothing.
+func (gd Grid[T]) SetWrap(p Point, c T) {
+	if gd.empty() {
+		return
+	}
+	q := p.Mod(gd.Range()).Add(gd.rg.Min)
+	gd.ug.Cells[q.Y*gd.ug.Width+q.X] = c
+}
+
+// empty reports whether the grid has no cells.
+func (gd Grid[T]) empty() bool {
+	return gd.ug == nil || gd.rg.Empty()
+}
+
+// NeighborsWrap appends to buf[:0] the positions p+d, for each offset d in
+// the stencil, wrapped around the range's edges, and returns the updated
+// slice. Positions are appended in stencil order. The same position may
+// appear several times if the stencil is large compared to the range.
+func (rg Range) NeighborsWrap(buf []Point, p Point, st Stencil) []Point {
+	buf = buf[:0]
+	if rg.Empty() {
+		return buf
+	}
+	for _, d := range st {
+		buf = append(buf, p.Add(d).Mod(rg))
+	}
+	return buf
+}
+
+// NeighborsWrap appends to buf[:0] the relative positions p+d, for each
+// offset d in the stencil, wrapped around the grid's edges, and returns the
+// updated slice. It is equivalent to gd.Range().NeighborsWrap(buf, p, st).
+func (gd Grid[T]) NeighborsWrap(buf []Point, p Point, st Stencil) []Point {
+	return gd.Range().NeighborsWrap(buf, p, st)
+}
+
+// NeighborValuesWrap appends to buf[:0] the cell values at positions p+d, for
+// each offset d in the stencil, wrapped around the grid's edges, and returns
+// the updated slice. Values are appended in stencil order.
+func (gd Grid[T]) NeighborValuesWrap(buf []T, p Point, st Stencil) []T {
+	buf = buf[:0]
+	if gd.empty() {
+		return buf
+	}
+	rg := gd.Range()
+	w := gd.ug.Width
+	for _, d := range st {
+		q := p.Add(d).Mod(rg).Add(gd.rg.Min)
+		buf = append(buf, gd.ug.Cells[q.Y*w+q.X])
+	}
+	return buf
+}
+
+// CopyWrap copies into gd the cells of the wrapped slice of src given by a
+// range rg relative to src: the range may cross src's edges, or even be
+// larger than src, in which case positions wrap around, as with AtWrap. It
+// returns the copied size, which is the minimum of the sizes of gd and rg
+// for each dimension. Cells are copied by contiguous runs, so that the
+// copy is done with few calls to the builtin copy.
+//
+// The result is independent of whether the two grids' referenced memory
+// overlaps or not: if it does, a temporary buffer is allocated.
+func (gd Grid[T]) CopyWrap(src Grid[T], rg Range) Point {
+	if gd.empty() || src.empty() || rg.Empty() {
+		return Point{}
+	}
+	max := gd.Size().Min(rg.Size())
+	if gd.ug == src.ug && gd.rg.Overlaps(src.rg) {
+		tmp := NewGrid[T](src.Size().X, src.Size().Y)
+		tmp.Copy(src)
+		src = tmp
+	}
+	smax := src.Size()
+	org := rg.Min.Mod(src.Range())
+	for y, sy := 0, org.Y; y < max.Y; y, sy = y+1, sy+1 {
+		if sy == smax.Y {
+			sy = 0
+		}
+		row, srow := gd.row(y)[:max.X], src.row(sy)
+		for x, sx := 0, org.X; x < max.X; sx = 0 {
+			x += copy(row[x:], srow[sx:])
+		}
+	}
+	return max
+}
+
+// Roll shifts the grid's content cyclically in place, so that the cell at
+// position p moves to position p+(dx,dy), wrapped around the grid's edges.
+// Lines and columns are moved with the overlap-safe Copy, using a temporary
+// buffer for the part that wraps around, whose size is at most half of the
+// grid.
+func (gd Grid[T]) Roll(dx, dy int) {
+	if gd.empty() {
+		return
+	}
+	max := gd.Size()
+	d := Point{dx, dy}.Mod(gd.Range())
+	if d.Y > 0 {
+		n := min(d.Y, max.Y-d.Y)
+		tmp := NewGrid[T](max.X, n)
+		if n == d.Y {
+			// save the last lines and move the others down
+			tmp.Copy(gd.Slice(gd.Range().Lines(max.Y-n, max.Y)))
+			gd.Slice(gd.Range().Lines(n, max.Y)).Copy(gd)
+			gd.Copy(tmp)
+		} else {
+			// save the first lines and move the others up
+			tmp.Copy(gd)
+			gd.Copy(gd.Slice(gd.Range().Lines(n, max.Y)))
+			gd.Slice(gd.Range().Lines(max.Y-n, max.Y)).Copy(tmp)
+		}
+	}
+	if d.X > 0 {
+		n := min(d.X, max.X-d.X)
+		tmp := NewGrid[T](n, max.Y)
+		if n == d.X {
+			tmp.Copy(gd.Slice(gd.Range().Columns(max.X-n, max.X)))
+			gd.Slice(gd.Range().Columns(n, max.X)).Copy(gd)
+			gd.Copy(tmp)
+		} else {
+			tmp.Copy(gd)
+			gd.Copy(gd.Slice(gd.Range().Columns(n, max.X)))
+			gd.Slice(gd.Range().Columns(max.X-n, max.X)).Copy(tmp)
+		}
+	}
+}
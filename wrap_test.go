@@ -0,0 +1,111 @@
+package grid
+
+import "testing"
+
+func TestAtSetWrap(t *testing.T) {
+	big := NewGrid[int](10, 8)
+	gd := big.Slice(NewRange(2, 1, 7, 5)) // 5x4
+	gd.Map(func(p Point, _ int) int { return 10*p.Y + p.X })
+	for _, tc := range []struct {
+		p    Point
+		want int
+	}{
+		{Point{1, 2}, 21},
+		{Point{-1, 0}, 4},
+		{Point{5, -1}, 30},
+		{Point{-6, 9}, 14},
+	} {
+		if c := gd.AtWrap(tc.p); c != tc.want {
+			t.Errorf("bad AtWrap(%v): %d", tc.p, c)
+		}
+	}
+	gd.SetWrap(Point{-1, -1}, 99)
+	if gd.At(Point{4, 3}) != 99 || big.At(Point{6, 4}) != 99 {
+		t.Errorf("bad SetWrap")
+	}
+	var empty Grid[int]
+	empty.SetWrap(Point{1, 1}, 2)
+	if empty.AtWrap(Point{1, 1}) != 0 {
+		t.Errorf("bad wrap access on empty grid")
+	}
+}
+
+func TestNeighborsWrap(t *testing.T) {
+	gd := NewGrid[int](4, 3)
+	gd.Map(func(p Point, _ int) int { return 10*p.Y + p.X })
+	var nbs []Point
+	nbs = gd.NeighborsWrap(nbs, Point{0, 0}, Moore)
+	want := []Point{{0, 2}, {1, 2}, {1, 0}, {1, 1}, {0, 1}, {3, 1}, {3, 0}, {3, 2}}
+	if len(nbs) != len(want) {
+		t.Fatalf("bad wrapped neighbors: %v", nbs)
+	}
+	for i, q := range nbs {
+		if q != want[i] {
+			t.Errorf("bad wrapped neighbor %d: %v", i, q)
+		}
+	}
+	var vals []int
+	vals = gd.NeighborValuesWrap(vals, Point{0, 0}, Moore)
+	for i, c := range vals {
+		if c != gd.At(want[i]) {
+			t.Errorf("bad wrapped neighbor value %d: %d", i, c)
+		}
+	}
+	if len((Range{}).NeighborsWrap(nbs, Point{}, Moore)) != 0 {
+		t.Errorf("neighbors in empty range")
+	}
+}
+
+func TestCopyWrap(t *testing.T) {
+	src := NewGrid[int](5, 4)
+	src.Map(func(p Point, _ int) int { return 10*p.Y + p.X })
+	for _, rg := range []Range{
+		NewRange(3, 2, 7, 5),
+		NewRange(-2, -3, 1, 0),
+		NewRange(1, 1, 13, 10),
+	} {
+		dst := NewGrid[int](8, 8)
+		max := dst.CopyWrap(src, rg)
+		if want := rg.Size().Min(Point{8, 8}); max != want {
+			t.Errorf("bad copied size for %v: %v", rg, max)
+		}
+		for p, c := range dst.All() {
+			want := 0
+			if p.In(Range{Max: max}) {
+				want = src.AtWrap(p.Add(rg.Min))
+			}
+			if c != want {
+				t.Errorf("bad wrapped copy for %v at %v: %d", rg, p, c)
+			}
+		}
+	}
+	// overlapping
+	want := NewGrid[int](5, 4)
+	want.CopyWrap(src, NewRange(2, 1, 7, 5))
+	src.CopyWrap(src, NewRange(2, 1, 7, 5))
+	if !equalGrids(src, want) {
+		t.Errorf("bad overlapping wrapped copy")
+	}
+}
+
+func TestRoll(t *testing.T) {
+	big := NewGrid[int](12, 9)
+	big.Map(func(p Point, _ int) int { return 100*p.Y + p.X })
+	gd := big.Slice(NewRange(1, 2, 8, 7)) // 7x5
+	orig := NewGrid[int](7, 5)
+	orig.Copy(gd)
+	for _, d := range []Point{{0, 0}, {2, 0}, {5, 0}, {0, 1}, {0, 4}, {3, -2}, {-8, 11}} {
+		gd.Copy(orig)
+		gd.Roll(d.X, d.Y)
+		for p, c := range orig.All() {
+			if q := p.Add(d); gd.AtWrap(q) != c {
+				t.Errorf("bad roll by %v at %v: %d vs %d", d, q, gd.AtWrap(q), c)
+			}
+		}
+		for p, c := range big.All() {
+			if !p.In(gd.Bounds()) && c != 100*p.Y+p.X {
+				t.Errorf("roll by %v modified cell out of slice at %v", d, p)
+			}
+		}
+	}
+}